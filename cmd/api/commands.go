@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/email"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/outbox"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/spam"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/storage"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/submissions"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/submission"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// commandUsage lists the subcommands accepted in place of starting the server
+const commandUsage = `Usage:
+  server                          start the HTTP server
+  server resend [-to addr] <id>   send a stored submission again
+  server reencrypt                rewrite stored records with the active encryption key
+  server train [-ham] <mbox>...   teach the spam classifier the messages in mbox files
+`
+
+// runCommand runs a subcommand and returns the process exit code
+func runCommand(name string, args []string) int {
+	var err error
+	switch name {
+	case "resend":
+		err = resendCommand(args)
+	case "reencrypt":
+		err = reencryptCommand(args)
+	case "train":
+		err = trainCommand(args)
+	case "help", "-h", "--help":
+		fmt.Print(commandUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, commandUsage)
+		return 2
+	}
+
+	if err != nil {
+		log.Printf("❌ %v", err)
+		return 1
+	}
+	return 0
+}
+
+// resendCommand sends a stored submission through the configured providers again.
+// The database is locked while the server runs; use the admin API then instead.
+func resendCommand(args []string) error {
+	flags := flag.NewFlagSet("resend", flag.ContinueOnError)
+	to := flags.String("to", "", "send to this address instead of the stored recipients")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("resend needs exactly one submission ID")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, keys, err := openStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	templates, err := email.NewTemplates(cfg.TemplateDir)
+	if err != nil {
+		return fmt.Errorf("failed to load email templates: %w", err)
+	}
+	outboxRepo, err := outbox.NewBoltOutbox(db, keys)
+	if err != nil {
+		return fmt.Errorf("failed to initialize outbox: %w", err)
+	}
+	submissionRepo, err := submissions.NewBoltSubmissions(db, keys)
+	if err != nil {
+		return fmt.Errorf("failed to initialize submissions: %w", err)
+	}
+
+	submissionUC := submission.NewSubmissionUseCase(submissionRepo, email.NewEmailRepository(cfg, templates), outboxRepo)
+	attempt, err := submissionUC.Resend(context.Background(), submission.ResendInput{
+		ID: flags.Arg(0),
+		To: *to,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Resent %s to %s via %s (message ID %s)", flags.Arg(0), attempt.Recipients, attempt.Provider, attempt.MessageID)
+	return nil
+}
+
+// reencryptCommand rewrites every stored record with the active encryption key
+// and a fresh email index. Run it after adding a key and making it active;
+// the old key can be removed once it has finished.
+func reencryptCommand(args []string) error {
+	if len(args) != 0 {
+		return errors.New("reencrypt takes no arguments")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, keys, err := openStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if keys == nil {
+		return errors.New("no encryption keys configured (ENCRYPTION_KEYS or ENCRYPTION_KEYS_FILE)")
+	}
+
+	outboxCount, err := outbox.Reencrypt(db, keys)
+	if err != nil {
+		return err
+	}
+	submissionCount, err := submissions.Reencrypt(db, keys)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ Re-encrypted %d submissions and %d outbox records with key %s", submissionCount, outboxCount, keys.ActiveKeyID())
+	return nil
+}
+
+// trainCommand teaches the spam classifier every message in the given mbox
+// files, as spam unless -ham is set. The server keeps its own copy of the
+// classifier and saves it when a submission is labelled, so the database is
+// held while training to make sure the server isn't running.
+func trainCommand(args []string) error {
+	flags := flag.NewFlagSet("train", flag.ContinueOnError)
+	ham := flags.Bool("ham", false, "the messages are wanted mail rather than spam")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errors.New("train needs at least one mbox file")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, keys, err := openStorage(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	classifier, err := spam.NewBayesClassifier(cfg.SpamBayesFile, keys)
+	if err != nil {
+		return err
+	}
+
+	label := entity.SpamLabelSpam
+	if *ham {
+		label = entity.SpamLabelHam
+	}
+
+	for _, path := range flags.Args() {
+		learned, skipped, err := trainFromMbox(classifier, path, !*ham)
+		if err != nil {
+			return err
+		}
+		log.Printf("📥 %s: %d messages learned as %s, %d empty or unreadable skipped", path, learned, label, skipped)
+	}
+
+	if err := classifier.Save(); err != nil {
+		return err
+	}
+
+	stats := classifier.Stats()
+	log.Printf("✅ Spam classifier now knows %d spam and %d ham messages (%s)", stats.SpamMessages, stats.HamMessages, cfg.SpamBayesFile)
+	return nil
+}
+
+// trainFromMbox teaches classifier the messages of one mbox file
+func trainFromMbox(classifier repository.SpamClassifier, path string, isSpam bool) (learned, skipped int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open mbox: %w", err)
+	}
+	defer f.Close()
+
+	unreadable, err := spam.ReadMbox(f, func(contact *entity.Contact) error {
+		if contact.Subject == "" && contact.Message == "" {
+			skipped++
+			return nil
+		}
+		classifier.Learn(contact, isSpam)
+		learned++
+		return nil
+	})
+	if err != nil {
+		return learned, skipped + unreadable, fmt.Errorf("%s: %w", path, err)
+	}
+	return learned, skipped + unreadable, nil
+}
+
+// openStorage loads the encryption keys and opens the embedded database.
+// The database is locked while the server runs.
+func openStorage(cfg *config.Config) (*bolt.DB, *encryption.Keyring, error) {
+	keys, err := encryption.NewKeyring(cfg.EncryptionKeys, cfg.EncryptionKeysFile, cfg.EncryptionActiveKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load encryption keys: %w", err)
+	}
+
+	db, err := storage.Open(cfg.DataDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w (is the server running? stop it first)", err)
+	}
+	return db, keys, nil
+}
@@ -1,16 +1,36 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/handler"
 	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/router"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/apikeys"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/captcha"
 	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
 	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/email"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/forms"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/outbox"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/queue"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/routing"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/spam"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/storage"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/submissions"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/antispam"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/apikey"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/challenge"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/classifier"
 	"github.com/andrianprasetya/go-mail-server/internal/usecase/contact"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/deadletter"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/privacy"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/submission"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -18,7 +38,15 @@ import (
 // Version is set at build time
 var Version = "1.0.0"
 
+// retentionInterval is how often the retention policy is applied
+const retentionInterval = time.Hour
+
 func main() {
+	// Subcommands work on the stored data instead of starting the server
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
 	log.Println("🚀 Starting Contact Form API...")
 
 	// Load configuration
@@ -27,25 +55,172 @@ func main() {
 		log.Fatalf("❌ Failed to load configuration: %v", err)
 	}
 
+	// Open embedded storage
+	db, err := storage.Open(cfg.DataDir)
+	if err != nil {
+		log.Fatalf("❌ Failed to open storage: %v", err)
+	}
+	defer db.Close()
+
+	// Load encryption keys (ENCRYPTION_KEYS / ENCRYPTION_KEYS_FILE); nil stores plaintext
+	keys, err := encryption.NewKeyring(cfg.EncryptionKeys, cfg.EncryptionKeysFile, cfg.EncryptionActiveKey)
+	if err != nil {
+		log.Fatalf("❌ Failed to load encryption keys: %v", err)
+	}
+
+	// Load email templates (built-in defaults, overridden by EMAIL_TEMPLATE_DIR)
+	templates, err := email.NewTemplates(cfg.TemplateDir)
+	if err != nil {
+		log.Fatalf("❌ Failed to load email templates: %v", err)
+	}
+
+	// Load form definitions (FORMS_CONFIG_FILE)
+	formRepo, err := forms.NewFileFormRepository(cfg.FormsFile)
+	if err != nil {
+		log.Fatalf("❌ Failed to load forms: %v", err)
+	}
+
+	// Load routing rules (ROUTING_RULES_FILE), falling back to RECEIVER_*
+	routes, err := routing.NewFileRoutingTable(cfg.RoutingFile, cfg.Recipients())
+	if err != nil {
+		log.Fatalf("❌ Failed to load routing rules: %v", err)
+	}
+
+	// Load the trained spam classifier (SPAM_BAYES_FILE) and the spam scorers
+	// (SPAM_SCORING_FILE); no scorers disables content scoring
+	bayes, err := spam.NewBayesClassifier(cfg.SpamBayesFile, keys)
+	if err != nil {
+		log.Fatalf("❌ Failed to load spam classifier: %v", err)
+	}
+	spamScorers, spamThresholds, err := spam.NewFileSpamScoring(cfg.SpamScoringFile, bayes)
+	if err != nil {
+		log.Fatalf("❌ Failed to load spam scoring: %v", err)
+	}
+
 	// Initialize infrastructure layer
-	emailRepo := email.NewSMTPRepository(cfg)
+	emailRepo := email.NewEmailRepository(cfg, templates)
+	outboxRepo, err := outbox.NewBoltOutbox(db, keys)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize outbox: %v", err)
+	}
+	deadLetterRepo, err := outbox.NewBoltDeadLetters(db, keys)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize dead letters: %v", err)
+	}
+	submissionRepo, err := submissions.NewBoltSubmissions(db, keys)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize submissions: %v", err)
+	}
+	apiKeyRepo, err := apikeys.NewBoltAPIKeys(db)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize API keys: %v", err)
+	}
+	captchaVerifier := captcha.NewCaptchaVerifier(cfg)
+	deliveryQueue := queue.NewWorkerPool(queue.Config{
+		Workers: cfg.QueueWorkers,
+		Size:    cfg.QueueSize,
+	})
+
+	// The auto-reply sends text chosen by the submitter to an address they
+	// choose, so it is only enabled when submissions pass some spam check
+	autoReply := cfg.AutoReplyEnabled
+	if autoReply && len(spamScorers) == 0 && !cfg.HasBotChecks() {
+		autoReply = false
+		log.Printf("⚠️  AUTO_REPLY_ENABLED is ignored: it needs spam scoring (SPAM_SCORING_FILE), CAPTCHA, proof-of-work or form tokens (SPAM_MIN_SUBMIT_SECONDS)")
+	}
 
 	// Initialize use case layer
-	contactUC := contact.NewContactUseCase(emailRepo)
+	contactUC := contact.NewContactUseCase(emailRepo, outboxRepo, submissionRepo, formRepo, routes, deliveryQueue, contact.RetryPolicy{
+		MaxAttempts: cfg.RetryMaxAttempts,
+		BaseDelay:   time.Duration(cfg.RetryBaseDelay) * time.Second,
+		MaxDelay:    time.Duration(cfg.RetryMaxDelay) * time.Second,
+		Jitter:      float64(cfg.RetryJitterPercent) / 100,
+	}, contact.AutoReplyPolicy{
+		Enabled:  autoReply,
+		Interval: time.Duration(cfg.AutoReplyInterval) * time.Minute,
+	}, contact.SpamPolicy{
+		Scorers:    spamScorers,
+		Thresholds: spamThresholds,
+	})
+	deadLetterUC := deadletter.NewDeadLetterUseCase(deadLetterRepo, outboxRepo, deliveryQueue)
+	submissionUC := submission.NewSubmissionUseCase(submissionRepo, emailRepo, outboxRepo)
+	classifierUC := classifier.NewClassifierUseCase(submissionRepo, bayes)
+	privacyUC := privacy.NewPrivacyUseCase(submissionRepo, outboxRepo, deadLetterRepo, privacy.RetentionPolicy{
+		Period:    time.Duration(cfg.RetentionDays) * 24 * time.Hour,
+		Anonymise: cfg.RetentionMode == config.RetentionAnonymise,
+	})
+	apiKeyUC := apikey.NewAPIKeyUseCase(apiKeyRepo, cfg.APIKeyRateLimit)
+	antiSpamUC, err := antispam.NewAntiSpamUseCase([]byte(cfg.FormTokenSecret), antispam.Policy{
+		HoneypotField: cfg.HoneypotField,
+		MinSubmitTime: time.Duration(cfg.MinSubmitSeconds) * time.Second,
+		TokenMaxAge:   time.Duration(cfg.FormTokenMaxAge) * time.Hour,
+	})
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize spam checks: %v", err)
+	}
+
+	var challengeUC challenge.UseCase
+	if cfg.PowEnabled {
+		challengeUC, err = challenge.NewChallengeUseCase([]byte(cfg.PowSecret), challenge.Policy{
+			BaseDifficulty: cfg.PowDifficulty,
+			MaxDifficulty:  cfg.PowMaxDifficulty,
+			TTL:            time.Duration(cfg.PowChallengeTTL) * time.Minute,
+			Window:         time.Duration(cfg.PowWindow) * time.Minute,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize proof-of-work challenges: %v", err)
+		}
+	}
+
+	// Start delivery workers and replay contacts left over from the previous run.
+	// The snapshot is taken before the server accepts requests, so contacts
+	// queued by new submissions are not replayed and delivered twice.
+	pending, err := contactUC.PendingDeliveries()
+	if err != nil {
+		log.Fatalf("❌ Failed to read pending deliveries: %v", err)
+	}
+	deliveryQueue.Start(contactUC.Deliver)
+	go func() {
+		resumed, err := contactUC.ResumePending(context.Background(), pending)
+		if err != nil {
+			log.Printf("❌ Failed to resume pending deliveries: %v", err)
+		}
+		if resumed > 0 {
+			log.Printf("📬 Resumed %d pending deliveries from outbox", resumed)
+		}
+	}()
+
+	// Apply the retention policy now and then periodically
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	defer stopRetention()
+	if cfg.RetentionDays > 0 {
+		go runRetention(retentionCtx, privacyUC)
+	}
 
 	// Initialize delivery layer (handlers)
-	contactHandler := handler.NewContactHandler(contactUC)
+	contactHandler := handler.NewContactHandler(contactUC, antiSpamUC, captchaVerifier, challengeUC)
 	healthHandler := handler.NewHealthHandler(Version)
+	deadLetterHandler := handler.NewDeadLetterHandler(deadLetterUC)
+	submissionHandler := handler.NewSubmissionHandler(submissionUC)
+	privacyHandler := handler.NewPrivacyHandler(privacyUC)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyUC)
+	classifierHandler := handler.NewClassifierHandler(classifierUC)
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:               "Contact Form API",
 		DisableStartupMessage: cfg.IsProduction(),
 		ErrorHandler:          customErrorHandler,
+
+		// c.IP() reads the proxy header only on connections from a trusted proxy
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          cfg.TrustedProxies,
+		EnableIPValidation:      true,
 	})
 
 	// Setup router
-	r := router.NewRouter(app, cfg, contactHandler, healthHandler)
+	r := router.NewRouter(app, cfg, contactHandler, healthHandler, deadLetterHandler, submissionHandler, privacyHandler, apiKeyHandler, classifierHandler, apiKeyUC)
 	r.Setup()
 
 	// Graceful shutdown
@@ -61,8 +236,55 @@ func main() {
 	}()
 
 	// Log startup info
-	log.Printf("📧 SMTP: %s:%d", cfg.SMTPHost, cfg.SMTPPort)
+	for i, p := range cfg.Providers {
+		if p.Type == config.ProviderSMTP {
+			log.Printf("📧 Provider %d: %s (%s %s:%d)", i+1, p.Name, p.Type, p.Host, p.Port)
+		} else {
+			log.Printf("📧 Provider %d: %s (%s)", i+1, p.Name, p.Type)
+		}
+	}
+	for _, f := range formRepo.List() {
+		log.Printf("📝 Form: %s (%d fields) at /api/forms/%s", f.Name, len(f.Fields), f.ID)
+	}
+	log.Printf("🔀 Routing: %d rules, default %s", len(routes.Rules), routes.Default)
+	log.Printf("📬 Delivery Queue: %d workers, capacity %d", cfg.QueueWorkers, cfg.QueueSize)
+	log.Printf("🔁 Retries: %d attempts, %ds base delay", cfg.RetryMaxAttempts, cfg.RetryBaseDelay)
+	if autoReply {
+		log.Printf("📨 Auto-reply: enabled, at most one per address every %d minutes", cfg.AutoReplyInterval)
+	}
+	log.Printf("💾 Data Directory: %s", cfg.DataDir)
+	if keys != nil {
+		log.Printf("🔐 Encryption: personal fields encrypted with key %s", keys.ActiveKeyID())
+	}
+	if cfg.RetentionDays > 0 {
+		log.Printf("🧹 Retention: %s submissions after %d days", cfg.RetentionMode, cfg.RetentionDays)
+	}
 	log.Printf("🔒 Rate Limit: %d requests/minute", cfg.RateLimit)
+	if cfg.ProxyHeader != "" {
+		log.Printf("🌐 Client IP: %s from %s", cfg.ProxyHeader, strings.Join(cfg.TrustedProxies, ", "))
+	}
+	if cfg.HoneypotField != "" {
+		log.Printf("🍯 Honeypot: submissions filling %q are dropped", cfg.HoneypotField)
+	}
+	if cfg.MinSubmitSeconds > 0 {
+		log.Printf("⏱️  Form Timing: submissions need a form token at least %ds old", cfg.MinSubmitSeconds)
+		if cfg.FormTokenSecret == "" {
+			log.Printf("⚠️  SPAM_TOKEN_SECRET is not set; form tokens won't survive a restart")
+		}
+	}
+	if captchaVerifier != nil {
+		log.Printf("🤖 CAPTCHA: %s", cfg.CaptchaProvider)
+	}
+	if cfg.PowEnabled {
+		log.Printf("🧮 Proof-of-Work: %d-%d bits, challenges at /api/challenge", cfg.PowDifficulty, cfg.PowMaxDifficulty)
+	}
+	if len(spamScorers) > 0 {
+		log.Printf("🚫 Spam Scoring: %d scorers, thresholds tag %.1f, quarantine %.1f, reject %.1f",
+			len(spamScorers), spamThresholds.Tag, spamThresholds.Quarantine, spamThresholds.Reject)
+	}
+	stats := bayes.Stats()
+	log.Printf("🧠 Spam Classifier: %d spam and %d ham messages learned (%s)", stats.SpamMessages, stats.HamMessages, cfg.SpamBayesFile)
+	log.Printf("🔑 API Keys: %d requests/minute per key by default", cfg.APIKeyRateLimit)
 	log.Printf("🌐 Allowed Origins: %v", cfg.AllowedOrigins)
 	log.Printf("✅ Server listening on port %s", cfg.AppPort)
 
@@ -70,6 +292,35 @@ func main() {
 	if err := app.Listen(":" + cfg.AppPort); err != nil {
 		log.Fatalf("❌ Failed to start server: %v", err)
 	}
+
+	// Drain the delivery queue once the server stops accepting requests
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	log.Println("📬 Draining delivery queue...")
+	if err := deliveryQueue.Shutdown(ctx); err != nil {
+		log.Printf("❌ Error draining delivery queue: %v", err)
+	}
+
+	log.Println("👋 Server stopped")
+}
+
+// runRetention applies the retention policy every retentionInterval until ctx is done
+func runRetention(ctx context.Context, privacyUC privacy.UseCase) {
+	ticker := time.NewTicker(retentionInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := privacyUC.ApplyRetention(ctx); err != nil {
+			log.Printf("❌ Failed to apply retention policy: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // customErrorHandler handles global errors
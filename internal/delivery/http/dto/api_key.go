@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// CreateAPIKeyRequest represents a request to issue an API key
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit int      `json:"rate_limit"` // requests per minute; 0 uses API_KEY_RATE_LIMIT
+}
+
+// APIKey represents an API key without its secret
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	RateLimit  int        `json:"rate_limit"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+// CreatedAPIKey is a new API key together with its token
+type CreatedAPIKey struct {
+	APIKey
+	Token string `json:"token"`
+}
+
+// APIKeyListResponse represents the API key list response
+type APIKeyListResponse struct {
+	Success bool     `json:"success"`
+	Data    []APIKey `json:"data"`
+}
+
+// CreateAPIKeyResponse represents the API key creation response
+type CreateAPIKeyResponse struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Data    *CreatedAPIKey `json:"data"`
+}
+
+// NewAPIKey converts an API key into its DTO
+func NewAPIKey(k *entity.APIKey) APIKey {
+	key := APIKey{
+		ID:        k.ID,
+		Name:      k.Name,
+		Scopes:    k.Scopes,
+		RateLimit: k.RateLimit,
+		CreatedAt: k.CreatedAt,
+	}
+	if !k.LastUsedAt.IsZero() {
+		lastUsed := k.LastUsedAt
+		key.LastUsedAt = &lastUsed
+	}
+	return key
+}
+
+// NewAPIKeyListResponse creates an API key list response
+func NewAPIKeyListResponse(keys []*entity.APIKey) *APIKeyListResponse {
+	data := make([]APIKey, 0, len(keys))
+	for _, k := range keys {
+		data = append(data, NewAPIKey(k))
+	}
+
+	return &APIKeyListResponse{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// NewCreateAPIKeyResponse creates an API key creation response
+func NewCreateAPIKeyResponse(k *entity.APIKey, token string) *CreateAPIKeyResponse {
+	return &CreateAPIKeyResponse{
+		Success: true,
+		Message: "API key created. Store the token now; it cannot be shown again.",
+		Data: &CreatedAPIKey{
+			APIKey: NewAPIKey(k),
+			Token:  token,
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+// ClassifierStats describes what the spam classifier has learned
+type ClassifierStats struct {
+	SpamMessages int `json:"spam_messages"`
+	HamMessages  int `json:"ham_messages"`
+	Tokens       int `json:"tokens"`
+}
+
+// ClassifierResponse represents the classifier stats response
+type ClassifierResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message,omitempty"`
+	Data    *ClassifierStats `json:"data"`
+}
+
+// NewClassifierResponse creates a classifier stats response
+func NewClassifierResponse(message string, stats entity.ClassifierStats) *ClassifierResponse {
+	return &ClassifierResponse{
+		Success: true,
+		Message: message,
+		Data: &ClassifierStats{
+			SpamMessages: stats.SpamMessages,
+			HamMessages:  stats.HamMessages,
+			Tokens:       stats.Tokens,
+		},
+	}
+}
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// DeadLetter represents a contact whose delivery failed after all retries
+type DeadLetter struct {
+	ID          string            `json:"id"`
+	FormID      string            `json:"form_id,omitempty"`
+	Name        string            `json:"name"`
+	Email       string            `json:"email"`
+	Subject     string            `json:"subject"`
+	Message     string            `json:"message"`
+	Fields      map[string]string `json:"fields,omitempty"`
+	SubmittedAt time.Time         `json:"submitted_at"`
+	Reason      string            `json:"reason"`
+	Attempts    int               `json:"attempts"`
+	FailedAt    time.Time         `json:"failed_at"`
+}
+
+// DeadLetterListResponse represents the dead letter list response
+type DeadLetterListResponse struct {
+	Success bool          `json:"success"`
+	Data    []*DeadLetter `json:"data"`
+}
+
+// NewDeadLetterListResponse creates a dead letter list response
+func NewDeadLetterListResponse(letters []*entity.DeadLetter) *DeadLetterListResponse {
+	data := make([]*DeadLetter, 0, len(letters))
+	for _, l := range letters {
+		data = append(data, &DeadLetter{
+			ID:          l.Contact.ID,
+			FormID:      l.Contact.FormID,
+			Name:        l.Contact.Name,
+			Email:       l.Contact.Email,
+			Subject:     l.Contact.Subject,
+			Message:     l.Contact.Message,
+			Fields:      fieldMap(l.Contact.Fields),
+			SubmittedAt: l.Contact.SubmittedAt,
+			Reason:      l.Reason,
+			Attempts:    l.Attempts,
+			FailedAt:    l.FailedAt,
+		})
+	}
+
+	return &DeadLetterListResponse{
+		Success: true,
+		Data:    data,
+	}
+}
+
+// fieldMap converts submitted form fields into a name/value map
+func fieldMap(fields []entity.FieldValue) map[string]string {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	m := make(map[string]string, len(fields))
+	for _, f := range fields {
+		m[f.Name] = f.Value
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// ErasureRequest represents a request to erase a submitter's data
+type ErasureRequest struct {
+	Email string `json:"email"`
+}
+
+// ErasedSubmission identifies a submission removed by an erasure request
+type ErasedSubmission struct {
+	ID          string    `json:"id"`
+	FormID      string    `json:"form_id,omitempty"`
+	Status      string    `json:"status"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+// erasureLogsNote explains why an erasure request has no log entries to remove
+const erasureLogsNote = "Logs identify submissions by ID only and hold no submitter data, so no log entries needed removing"
+
+// Erasure describes what an erasure request removed
+type Erasure struct {
+	Email       string             `json:"email"`
+	Submissions []ErasedSubmission `json:"submissions"`
+	Outbox      []string           `json:"outbox"`
+	Logs        string             `json:"logs"`
+}
+
+// ErasureResponse represents the erasure response
+type ErasureResponse struct {
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Data    *Erasure `json:"data"`
+}
+
+// NewErasureResponse creates an erasure response
+func NewErasureResponse(email string, submissions []*entity.Submission, outbox []string) *ErasureResponse {
+	erasure := &Erasure{
+		Email:       email,
+		Submissions: make([]ErasedSubmission, 0, len(submissions)),
+		Outbox:      outbox,
+		Logs:        erasureLogsNote,
+	}
+	if erasure.Outbox == nil {
+		erasure.Outbox = []string{}
+	}
+	for _, s := range submissions {
+		erasure.Submissions = append(erasure.Submissions, ErasedSubmission{
+			ID:          s.Contact.ID,
+			FormID:      s.Contact.FormID,
+			Status:      string(s.Status),
+			SubmittedAt: s.Contact.SubmittedAt,
+		})
+	}
+
+	return &ErasureResponse{
+		Success: true,
+		Message: "Submitter data erased",
+		Data:    erasure,
+	}
+}
@@ -1,9 +1,61 @@
 package dto
 
+import (
+	"fmt"
+	"strconv"
+)
+
 // ContactRequest represents the incoming contact form request
 type ContactRequest struct {
-	Name    string `json:"name"`
-	Email   string `json:"email"`
-	Subject string `json:"subject"`
-	Message string `json:"message"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Subject      string `json:"subject"`
+	Message      string `json:"message"`
+	CaptchaToken string `json:"captcha_token" form:"captcha_token"` // response token of the CAPTCHA widget
+}
+
+// CaptchaTokenField is the field carrying the CAPTCHA response token
+const CaptchaTokenField = "captcha_token"
+
+// FormRequest represents an incoming configured form submission as field name/value pairs
+type FormRequest map[string]any
+
+// Strings returns the submitted values that are strings, numbers or booleans,
+// skipping any others instead of rejecting the request
+func (r FormRequest) Strings() map[string]string {
+	values := make(map[string]string, len(r))
+	for name, raw := range r {
+		if v, ok := formValue(raw); ok {
+			values[name] = v
+		}
+	}
+	return values
+}
+
+// Values converts the submitted values to strings; nested objects and arrays are rejected
+func (r FormRequest) Values() (map[string]string, error) {
+	values := make(map[string]string, len(r))
+	for name, raw := range r {
+		v, ok := formValue(raw)
+		if !ok {
+			return nil, fmt.Errorf("field %s must be a string, number or boolean", name)
+		}
+		values[name] = v
+	}
+	return values, nil
+}
+
+// formValue converts a submitted JSON value to a string
+func formValue(raw any) (string, bool) {
+	switch v := raw.(type) {
+	case nil:
+		return "", true
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
 }
@@ -1,9 +1,29 @@
 package dto
 
+import "time"
+
+// Error codes telling clients why a request was refused
+const (
+	ErrorCodeCaptchaRequired    = "captcha_required"
+	ErrorCodeCaptchaInvalid     = "captcha_invalid"
+	ErrorCodeCaptchaUnavailable = "captcha_unavailable"
+	ErrorCodeChallengeRequired  = "challenge_required"
+	ErrorCodeChallengeInvalid   = "challenge_invalid"
+	ErrorCodeChallengeExpired   = "challenge_expired"
+)
+
 // Response represents a generic API response
 type Response struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
+	Code    string `json:"code,omitempty"` // set on errors clients need to tell apart
+}
+
+// SubmissionResponse represents an accepted submission response
+type SubmissionResponse struct {
+	Success      bool   `json:"success"`
+	Message      string `json:"message"`
+	SubmissionID string `json:"submission_id"`
 }
 
 // HealthResponse represents health check response
@@ -28,3 +48,75 @@ func NewErrorResponse(message string) *Response {
 		Message: message,
 	}
 }
+
+// NewCodedErrorResponse creates an error response with an error code
+func NewCodedErrorResponse(code, message string) *Response {
+	return &Response{
+		Success: false,
+		Message: message,
+		Code:    code,
+	}
+}
+
+// NewSubmissionResponse creates an accepted submission response
+func NewSubmissionResponse(message, submissionID string) *SubmissionResponse {
+	return &SubmissionResponse{
+		Success:      true,
+		Message:      message,
+		SubmissionID: submissionID,
+	}
+}
+
+// FormToken represents a signed "form rendered at" token
+type FormToken struct {
+	Token     string    `json:"token"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FormTokenResponse represents the form token response
+type FormTokenResponse struct {
+	Success bool       `json:"success"`
+	Data    *FormToken `json:"data"`
+}
+
+// NewFormTokenResponse creates a form token response
+func NewFormTokenResponse(token string, issuedAt, expiresAt time.Time) *FormTokenResponse {
+	return &FormTokenResponse{
+		Success: true,
+		Data: &FormToken{
+			Token:     token,
+			IssuedAt:  issuedAt,
+			ExpiresAt: expiresAt,
+		},
+	}
+}
+
+// Challenge represents a proof-of-work challenge
+type Challenge struct {
+	Challenge  string    `json:"challenge"`
+	Nonce      string    `json:"nonce"`
+	Difficulty int       `json:"difficulty"`
+	Algorithm  string    `json:"algorithm"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// ChallengeResponse represents the proof-of-work challenge response
+type ChallengeResponse struct {
+	Success bool       `json:"success"`
+	Data    *Challenge `json:"data"`
+}
+
+// NewChallengeResponse creates a proof-of-work challenge response
+func NewChallengeResponse(challenge, nonce string, difficulty int, expiresAt time.Time) *ChallengeResponse {
+	return &ChallengeResponse{
+		Success: true,
+		Data: &Challenge{
+			Challenge:  challenge,
+			Nonce:      nonce,
+			Difficulty: difficulty,
+			Algorithm:  "sha256",
+			ExpiresAt:  expiresAt,
+		},
+	}
+}
@@ -0,0 +1,267 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// dateLayout is the date-only format accepted for submission date filters
+const dateLayout = "2006-01-02"
+
+// SubmissionQuery represents the query string of the submission list endpoint
+type SubmissionQuery struct {
+	Page    int    `query:"page"`
+	PerPage int    `query:"per_page"`
+	From    string `query:"from"`
+	To      string `query:"to"`
+	Form    string `query:"form"`
+	Status  string `query:"status"`
+	Email   string `query:"email"`
+	Q       string `query:"q"`
+}
+
+// Filter converts the query into a submission filter. Dates are RFC 3339
+// timestamps or plain dates; a plain "to" date includes that whole day.
+func (q *SubmissionQuery) Filter() (entity.SubmissionFilter, error) {
+	filter := entity.SubmissionFilter{
+		FormID: q.Form,
+		Status: entity.SubmissionStatus(q.Status),
+		Email:  q.Email,
+		Query:  q.Q,
+	}
+
+	switch filter.Status {
+	case "", entity.SubmissionQueued, entity.SubmissionSent, entity.SubmissionFailed, entity.SubmissionQuarantined:
+	default:
+		return filter, fmt.Errorf("status must be one of %s, %s, %s or %s",
+			entity.SubmissionQueued, entity.SubmissionSent, entity.SubmissionFailed, entity.SubmissionQuarantined)
+	}
+
+	var err error
+	if filter.Since, err = parseDate(q.From, false); err != nil {
+		return filter, fmt.Errorf("from: %w", err)
+	}
+	if filter.Until, err = parseDate(q.To, true); err != nil {
+		return filter, fmt.Errorf("to: %w", err)
+	}
+
+	return filter, nil
+}
+
+// parseDate parses an RFC 3339 timestamp or a date; endOfDay moves a date to the next midnight
+func parseDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date (YYYY-MM-DD) or RFC 3339 timestamp", value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// Submission represents a stored submission
+type Submission struct {
+	ID          string            `json:"id"`
+	FormID      string            `json:"form_id,omitempty"`
+	Name        string            `json:"name"`
+	Email       string            `json:"email"`
+	Subject     string            `json:"subject"`
+	Message     string            `json:"message"`
+	Fields      map[string]string `json:"fields,omitempty"`
+	Route       string            `json:"route,omitempty"`
+	To          []string          `json:"to,omitempty"`
+	CC          []string          `json:"cc,omitempty"`
+	BCC         []string          `json:"bcc,omitempty"`
+	SubmittedAt time.Time         `json:"submitted_at"`
+	ClientIP    string            `json:"client_ip,omitempty"`
+	UserAgent   string            `json:"user_agent,omitempty"`
+	Origin      string            `json:"origin,omitempty"`
+	Status      string            `json:"status"`
+	Provider    string            `json:"provider,omitempty"`
+	MessageID   string            `json:"message_id,omitempty"`
+	Attempts    int               `json:"attempts"`
+	Reason      string            `json:"reason,omitempty"`
+	Spam        *SpamScore        `json:"spam,omitempty"`
+	Label       string            `json:"label,omitempty"` // spam or ham, once an admin has trained the classifier with it
+	History     []DeliveryAttempt `json:"history,omitempty"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// SpamScore represents the content score of a submission
+type SpamScore struct {
+	Total  float64         `json:"total"`
+	Action string          `json:"action,omitempty"`
+	Rules  []SpamRuleScore `json:"rules,omitempty"`
+}
+
+// SpamRuleScore represents one rule's contribution to a spam score
+type SpamRuleScore struct {
+	Rule   string  `json:"rule"`
+	Points float64 `json:"points"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+// DeliveryAttempt represents one attempt to send a submission's notification
+type DeliveryAttempt struct {
+	At        time.Time `json:"at"`
+	Resend    bool      `json:"resend"`
+	To        []string  `json:"to,omitempty"`
+	CC        []string  `json:"cc,omitempty"`
+	BCC       []string  `json:"bcc,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	MessageID string    `json:"message_id,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ResendRequest represents a request to send a stored submission again
+type ResendRequest struct {
+	To string `json:"to"` // optional; replaces the stored recipients
+}
+
+// ResendResponse represents the outcome of a resend
+type ResendResponse struct {
+	Success bool             `json:"success"`
+	Message string           `json:"message"`
+	Data    *DeliveryAttempt `json:"data,omitempty"`
+}
+
+// Pagination describes the page returned by a list endpoint
+type Pagination struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// SubmissionListResponse represents the submission list response
+type SubmissionListResponse struct {
+	Success    bool          `json:"success"`
+	Data       []*Submission `json:"data"`
+	Pagination Pagination    `json:"pagination"`
+}
+
+// SubmissionDetailResponse represents a single submission response
+type SubmissionDetailResponse struct {
+	Success bool        `json:"success"`
+	Data    *Submission `json:"data"`
+}
+
+// NewSubmission converts a stored submission into its response form
+func NewSubmission(s *entity.Submission) *Submission {
+	c := s.Contact
+	return &Submission{
+		ID:          c.ID,
+		FormID:      c.FormID,
+		Name:        c.Name,
+		Email:       c.Email,
+		Subject:     c.Subject,
+		Message:     c.Message,
+		Fields:      fieldMap(c.Fields),
+		Route:       c.Route,
+		To:          c.Recipients.To,
+		CC:          c.Recipients.CC,
+		BCC:         c.Recipients.BCC,
+		SubmittedAt: c.SubmittedAt,
+		ClientIP:    s.ClientIP,
+		UserAgent:   s.UserAgent,
+		Origin:      s.Origin,
+		Status:      string(s.Status),
+		Provider:    s.Provider,
+		MessageID:   s.MessageID,
+		Attempts:    s.Attempts,
+		Reason:      s.Reason,
+		Spam:        newSpamScore(c.Spam),
+		Label:       string(s.Label),
+		History:     newDeliveryAttempts(s.History),
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// newSpamScore converts a spam score into its response form
+func newSpamScore(score *entity.SpamScore) *SpamScore {
+	if score == nil {
+		return nil
+	}
+
+	out := &SpamScore{Total: score.Total, Action: string(score.Action)}
+	for _, r := range score.Rules {
+		out.Rules = append(out.Rules, SpamRuleScore{Rule: r.Rule, Points: r.Points, Detail: r.Detail})
+	}
+	return out
+}
+
+// NewDeliveryAttempt converts a delivery attempt into its response form
+func NewDeliveryAttempt(a *entity.DeliveryAttempt) *DeliveryAttempt {
+	return &DeliveryAttempt{
+		At:        a.At,
+		Resend:    a.Resend,
+		To:        a.Recipients.To,
+		CC:        a.Recipients.CC,
+		BCC:       a.Recipients.BCC,
+		Provider:  a.Provider,
+		MessageID: a.MessageID,
+		Error:     a.Error,
+	}
+}
+
+// newDeliveryAttempts converts a delivery history into its response form
+func newDeliveryAttempts(history []entity.DeliveryAttempt) []DeliveryAttempt {
+	if len(history) == 0 {
+		return nil
+	}
+
+	attempts := make([]DeliveryAttempt, 0, len(history))
+	for i := range history {
+		attempts = append(attempts, *NewDeliveryAttempt(&history[i]))
+	}
+	return attempts
+}
+
+// NewResendResponse creates a resend response
+func NewResendResponse(success bool, message string, attempt *entity.DeliveryAttempt) *ResendResponse {
+	resp := &ResendResponse{
+		Success: success,
+		Message: message,
+	}
+	if attempt != nil {
+		resp.Data = NewDeliveryAttempt(attempt)
+	}
+	return resp
+}
+
+// NewSubmissionListResponse creates a submission list response
+func NewSubmissionListResponse(submissions []*entity.Submission, page, perPage, total int) *SubmissionListResponse {
+	data := make([]*Submission, 0, len(submissions))
+	for _, s := range submissions {
+		data = append(data, NewSubmission(s))
+	}
+
+	return &SubmissionListResponse{
+		Success: true,
+		Data:    data,
+		Pagination: Pagination{
+			Page:       page,
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: (total + perPage - 1) / perPage,
+		},
+	}
+}
+
+// NewSubmissionDetailResponse creates a single submission response
+func NewSubmissionDetailResponse(s *entity.Submission) *SubmissionDetailResponse {
+	return &SubmissionDetailResponse{
+		Success: true,
+		Data:    NewSubmission(s),
+	}
+}
@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// Submission export formats
+const (
+	ExportCSV   = "csv"
+	ExportJSONL = "jsonl"
+)
+
+// SubmissionCSVHeader is the header row of a CSV export
+var SubmissionCSVHeader = []string{
+	"id", "submitted_at", "form_id", "name", "email", "subject", "message", "fields",
+	"route", "to", "cc", "status", "provider", "message_id", "attempts", "reason",
+	"spam_score", "client_ip", "user_agent", "origin", "updated_at",
+}
+
+// NewSubmissionCSVRecord converts a submission into a CSV export row. Form
+// fields are encoded as a JSON object so the column count stays fixed.
+func NewSubmissionCSVRecord(s *entity.Submission) []string {
+	c := s.Contact
+
+	fields := ""
+	if m := fieldMap(c.Fields); m != nil {
+		if b, err := json.Marshal(m); err == nil {
+			fields = string(b)
+		}
+	}
+
+	spamScore := ""
+	if c.Spam != nil {
+		spamScore = strconv.FormatFloat(c.Spam.Total, 'f', 1, 64)
+	}
+
+	record := []string{
+		c.ID,
+		c.SubmittedAt.Format(time.RFC3339),
+		c.FormID,
+		c.Name,
+		c.Email,
+		c.Subject,
+		c.Message,
+		fields,
+		c.Route,
+		strings.Join(c.Recipients.To, ", "),
+		strings.Join(c.Recipients.CC, ", "),
+		string(s.Status),
+		s.Provider,
+		s.MessageID,
+		strconv.Itoa(s.Attempts),
+		s.Reason,
+		spamScore,
+		s.ClientIP,
+		s.UserAgent,
+		s.Origin,
+		s.UpdatedAt.Format(time.RFC3339),
+	}
+
+	for i, v := range record {
+		record[i] = escapeFormula(v)
+	}
+	return record
+}
+
+// escapeFormula prefixes values that spreadsheets would run as a formula.
+// Submitters control most columns, so "=HYPERLINK(...)" must stay plain text.
+func escapeFormula(v string) string {
+	if v == "" {
+		return v
+	}
+	switch v[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + v
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/apikey"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyHandler handles API key admin HTTP requests
+type APIKeyHandler struct {
+	apiKeyUC apikey.UseCase
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(apiKeyUC apikey.UseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUC: apiKeyUC,
+	}
+}
+
+// Create issues a new API key
+// @Summary Create API key
+// @Description Issues an API key for backend services. The token is only returned by this call.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateAPIKeyRequest true "Key name, scopes and rate limit"
+// @Success 201 {object} dto.CreateAPIKeyResponse
+// @Failure 400 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/api-keys [post]
+func (h *APIKeyHandler) Create(c *fiber.Ctx) error {
+	var request dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("Invalid request body"),
+		)
+	}
+
+	output, err := h.apiKeyUC.Create(c.Context(), apikey.CreateInput{
+		Name:      request.Name,
+		Scopes:    request.Scopes,
+		RateLimit: request.RateLimit,
+	})
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidKeySpec) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(
+				dto.NewErrorResponse(err.Error()),
+			)
+		}
+		log.Printf("[Handler] Failed to create API key: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to create API key"),
+		)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(
+		dto.NewCreateAPIKeyResponse(output.Key, output.Token),
+	)
+}
+
+// List returns all API keys without their secrets
+// @Summary List API keys
+// @Description Returns every API key with its scopes, rate limit and last use
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIKeyListResponse
+// @Failure 401 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/api-keys [get]
+func (h *APIKeyHandler) List(c *fiber.Ctx) error {
+	keys, err := h.apiKeyUC.List(c.Context())
+	if err != nil {
+		log.Printf("[Handler] Failed to list API keys: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to list API keys"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewAPIKeyListResponse(keys),
+	)
+}
+
+// Revoke deletes an API key
+// @Summary Revoke API key
+// @Description Deletes an API key; requests using it are rejected immediately
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "API key ID"
+// @Success 200 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	if err := h.apiKeyUC.Revoke(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(
+				dto.NewErrorResponse("API key not found"),
+			)
+		}
+		log.Printf("[Handler] Failed to revoke API key: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to revoke API key"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("API key revoked"),
+	)
+}
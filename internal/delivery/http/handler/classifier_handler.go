@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/classifier"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClassifierHandler handles spam classifier training admin HTTP requests
+type ClassifierHandler struct {
+	classifierUC classifier.UseCase
+}
+
+// NewClassifierHandler creates a new classifier handler
+func NewClassifierHandler(classifierUC classifier.UseCase) *ClassifierHandler {
+	return &ClassifierHandler{
+		classifierUC: classifierUC,
+	}
+}
+
+// Stats returns what the spam classifier has learned
+// @Summary Spam classifier stats
+// @Description Returns the number of spam and ham messages and distinct tokens the classifier has learned
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ClassifierResponse
+// @Failure 401 {object} dto.Response
+// @Router /api/admin/classifier [get]
+func (h *ClassifierHandler) Stats(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewClassifierResponse("", h.classifierUC.Stats(c.Context())),
+	)
+}
+
+// MarkSpam trains the classifier with a submission as spam
+// @Summary Mark submission as spam
+// @Description Trains the spam classifier with a stored submission as spam, reversing an earlier ham label
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Submission ID"
+// @Success 200 {object} dto.ClassifierResponse
+// @Failure 401 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/submissions/{id}/spam [post]
+func (h *ClassifierHandler) MarkSpam(c *fiber.Ctx) error {
+	return h.label(c, entity.SpamLabelSpam)
+}
+
+// MarkHam trains the classifier with a submission as wanted mail
+// @Summary Mark submission as ham
+// @Description Trains the spam classifier with a stored submission as wanted mail, reversing an earlier spam label
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Submission ID"
+// @Success 200 {object} dto.ClassifierResponse
+// @Failure 401 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/submissions/{id}/ham [post]
+func (h *ClassifierHandler) MarkHam(c *fiber.Ctx) error {
+	return h.label(c, entity.SpamLabelHam)
+}
+
+// label trains the classifier with the submission in the URL
+func (h *ClassifierHandler) label(c *fiber.Ctx, label entity.SpamLabel) error {
+	output, err := h.classifierUC.Label(c.Context(), classifier.LabelInput{
+		ID:    c.Params("id"),
+		Label: label,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, classifier.ErrSubmissionNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(
+				dto.NewErrorResponse("Submission not found"),
+			)
+		case errors.Is(err, classifier.ErrNothingToLearn):
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(
+				dto.NewErrorResponse(err.Error()),
+			)
+		}
+		log.Printf("[Handler] Failed to label submission: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to label submission"),
+		)
+	}
+
+	message := fmt.Sprintf("Submission marked as %s", label)
+	if output.Previous == label {
+		message = fmt.Sprintf("Submission was already marked as %s", label)
+	}
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewClassifierResponse(message, output.Stats),
+	)
+}
@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"log"
+	"strings"
 
 	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/middleware"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/antispam"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/challenge"
 	"github.com/andrianprasetya/go-mail-server/internal/usecase/contact"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,26 +17,86 @@ import (
 
 // ContactHandler handles contact form HTTP requests
 type ContactHandler struct {
-	contactUC contact.UseCase
+	contactUC   contact.UseCase
+	antiSpamUC  antispam.UseCase
+	captcha     repository.CaptchaVerifier // nil when CAPTCHA checks are disabled
+	challengeUC challenge.UseCase          // nil when proof-of-work is disabled
 }
 
 // NewContactHandler creates a new contact handler
-func NewContactHandler(contactUC contact.UseCase) *ContactHandler {
+func NewContactHandler(
+	contactUC contact.UseCase,
+	antiSpamUC antispam.UseCase,
+	captcha repository.CaptchaVerifier,
+	challengeUC challenge.UseCase,
+) *ContactHandler {
 	return &ContactHandler{
-		contactUC: contactUC,
+		contactUC:   contactUC,
+		antiSpamUC:  antiSpamUC,
+		captcha:     captcha,
+		challengeUC: challengeUC,
 	}
 }
 
+// Token issues a signed "form rendered at" token for the submission timing check
+// @Summary Get form token
+// @Description Returns a signed timestamp to send as form_token with the submission
+// @Tags contact
+// @Produce json
+// @Success 200 {object} dto.FormTokenResponse
+// @Failure 500 {object} dto.Response
+// @Router /api/contact/token [get]
+func (h *ContactHandler) Token(c *fiber.Ctx) error {
+	token, err := h.antiSpamUC.IssueToken(c.Context())
+	if err != nil {
+		log.Printf("[Handler] Failed to issue form token: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to issue form token"),
+		)
+	}
+
+	// Every page load needs a fresh token
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewFormTokenResponse(token.Value, token.IssuedAt, token.ExpiresAt),
+	)
+}
+
+// Challenge issues a proof-of-work challenge to solve before submitting
+// @Summary Get proof-of-work challenge
+// @Description Returns a signed nonce and difficulty; send the challenge and a solution as pow_challenge and pow_solution
+// @Tags contact
+// @Produce json
+// @Success 200 {object} dto.ChallengeResponse
+// @Failure 500 {object} dto.Response
+// @Router /api/challenge [get]
+func (h *ContactHandler) Challenge(c *fiber.Ctx) error {
+	ch, err := h.challengeUC.Issue(c.Context(), c.IP())
+	if err != nil {
+		log.Printf("[Handler] Failed to issue challenge: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to issue challenge"),
+		)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewChallengeResponse(ch.Value, ch.Nonce, ch.Difficulty, ch.ExpiresAt),
+	)
+}
+
 // HandleContact processes contact form submissions
 // @Summary Submit contact form
-// @Description Receives contact form data and sends email to site owner
+// @Description Receives contact form data and queues an email to the site owner
 // @Tags contact
 // @Accept json
 // @Produce json
 // @Param request body dto.ContactRequest true "Contact form data"
-// @Success 200 {object} dto.Response
+// @Success 202 {object} dto.SubmissionResponse
 // @Failure 400 {object} dto.Response
-// @Failure 500 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 503 {object} dto.Response
 // @Router /api/contact [post]
 func (h *ContactHandler) HandleContact(c *fiber.Ctx) error {
 	// Parse request body
@@ -42,31 +108,252 @@ func (h *ContactHandler) HandleContact(c *fiber.Ctx) error {
 		)
 	}
 
+	// The honeypot, form token and widget fields aren't part of ContactRequest
+	fields := submittedFields(c)
+	if handled, err := h.screen(c, fields); handled {
+		return err
+	}
+
+	token := request.CaptchaToken
+	if token == "" && h.captcha != nil {
+		token = fields[h.captcha.ResponseField()]
+	}
+	if handled, err := h.verifyCaptcha(c, token); handled {
+		return err
+	}
+	if handled, err := h.verifyChallenge(c, fields); handled {
+		return err
+	}
+
 	// Create use case input
 	input := &contact.ContactInput{
 		Name:    request.Name,
 		Email:   request.Email,
 		Subject: request.Subject,
 		Message: request.Message,
+		Client:  clientInfo(c),
 	}
 
 	// Execute use case
 	output, err := h.contactUC.SendContact(c.Context(), input)
 	if err != nil {
-		// Check if it's a validation error (client error)
-		if !output.Success && output.Message != "Failed to send email. Please try again later." {
-			return c.Status(fiber.StatusUnprocessableEntity).JSON(
+		// Queue unavailable (server error)
+		if errors.Is(err, contact.ErrDeliveryUnavailable) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(
 				dto.NewErrorResponse(output.Message),
 			)
 		}
-		// Server error
-		return c.Status(fiber.StatusInternalServerError).JSON(
+		// Validation error (client error)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
 			dto.NewErrorResponse(output.Message),
 		)
 	}
 
-	// Return success response
-	return c.Status(fiber.StatusOK).JSON(
-		dto.NewSuccessResponse(output.Message),
+	// Return accepted response; delivery happens asynchronously
+	return c.Status(fiber.StatusAccepted).JSON(
+		dto.NewSubmissionResponse(output.Message, output.SubmissionID),
+	)
+}
+
+// HandleForm processes submissions of a configured form
+// @Summary Submit a configured form
+// @Description Validates the fields against the form definition and queues an email to the form's receiver
+// @Tags forms
+// @Accept json
+// @Produce json
+// @Param formID path string true "Form ID"
+// @Param request body dto.FormRequest true "Form field values"
+// @Success 202 {object} dto.SubmissionResponse
+// @Failure 400 {object} dto.Response
+// @Failure 403 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 503 {object} dto.Response
+// @Router /api/forms/{formID} [post]
+func (h *ContactHandler) HandleForm(c *fiber.Ctx) error {
+	// Parse request body
+	var request dto.FormRequest
+	if err := c.BodyParser(&request); err != nil {
+		log.Printf("[Handler] Failed to parse request body: %v", err)
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("Invalid request body"),
+		)
+	}
+
+	values, err := request.Values()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse(err.Error()),
+		)
+	}
+
+	if handled, err := h.screen(c, values); handled {
+		return err
+	}
+
+	token := values[dto.CaptchaTokenField]
+	delete(values, dto.CaptchaTokenField)
+	if h.captcha != nil {
+		if token == "" {
+			token = values[h.captcha.ResponseField()]
+		}
+		delete(values, h.captcha.ResponseField())
+	}
+	if handled, err := h.verifyCaptcha(c, token); handled {
+		return err
+	}
+	if handled, err := h.verifyChallenge(c, values); handled {
+		return err
+	}
+
+	// Execute use case
+	output, err := h.contactUC.SubmitForm(c.Context(), &contact.FormInput{
+		FormID: c.Params("formID"),
+		Values: values,
+		Client: clientInfo(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, contact.ErrFormNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(
+				dto.NewErrorResponse(output.Message),
+			)
+		case errors.Is(err, contact.ErrDeliveryUnavailable):
+			return c.Status(fiber.StatusServiceUnavailable).JSON(
+				dto.NewErrorResponse(output.Message),
+			)
+		}
+		// Validation error (client error)
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(
+			dto.NewErrorResponse(output.Message),
+		)
+	}
+
+	// Return accepted response; delivery happens asynchronously
+	return c.Status(fiber.StatusAccepted).JSON(
+		dto.NewSubmissionResponse(output.Message, output.SubmissionID),
 	)
 }
+
+// screen runs the spam checks on the submitted values, removing the honeypot
+// and form token fields. It reports whether the request has been answered:
+// spam gets the same response as an accepted submission but is dropped.
+func (h *ContactHandler) screen(c *fiber.Ctx, values map[string]string) (bool, error) {
+	verdict, err := h.antiSpamUC.Inspect(c.Context(), antispam.InspectInput{
+		Values:  values,
+		Trusted: middleware.HasAPIKey(c),
+	})
+	if err != nil {
+		if errors.Is(err, antispam.ErrTokenExpired) {
+			return true, c.Status(fiber.StatusUnprocessableEntity).JSON(
+				dto.NewErrorResponse("The form has expired. Please reload the page and try again."),
+			)
+		}
+		log.Printf("[Handler] Failed to check submission for spam: %v", err)
+		return true, c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Internal server error"),
+		)
+	}
+
+	if verdict.Spam {
+		return true, c.Status(fiber.StatusAccepted).JSON(
+			dto.NewSubmissionResponse(contact.AcceptedMessage, verdict.DecoyID),
+		)
+	}
+	return false, nil
+}
+
+// verifyCaptcha checks the CAPTCHA response token when a verifier is configured.
+// It reports whether the request has been answered with an error.
+func (h *ContactHandler) verifyCaptcha(c *fiber.Ctx, token string) (bool, error) {
+	if h.captcha == nil || middleware.HasAPIKey(c) {
+		return false, nil
+	}
+
+	if token == "" {
+		return true, c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewCodedErrorResponse(dto.ErrorCodeCaptchaRequired, "Please complete the CAPTCHA"),
+		)
+	}
+
+	if err := h.captcha.Verify(c.Context(), token, c.IP()); err != nil {
+		if errors.Is(err, repository.ErrCaptchaRejected) {
+			return true, c.Status(fiber.StatusForbidden).JSON(
+				dto.NewCodedErrorResponse(dto.ErrorCodeCaptchaInvalid, "CAPTCHA verification failed. Please try again."),
+			)
+		}
+		log.Printf("[Handler] Failed to verify CAPTCHA: %v", err)
+		return true, c.Status(fiber.StatusServiceUnavailable).JSON(
+			dto.NewCodedErrorResponse(dto.ErrorCodeCaptchaUnavailable, "CAPTCHA verification is unavailable. Please try again later."),
+		)
+	}
+
+	return false, nil
+}
+
+// verifyChallenge checks the solved proof-of-work challenge when challenges
+// are enabled, removing its fields from the values. It reports whether the
+// request has been answered with an error.
+func (h *ContactHandler) verifyChallenge(c *fiber.Ctx, values map[string]string) (bool, error) {
+	input := challenge.VerifyInput{
+		Challenge: values[challenge.ChallengeField],
+		Solution:  values[challenge.SolutionField],
+		ClientIP:  c.IP(),
+	}
+	delete(values, challenge.ChallengeField)
+	delete(values, challenge.SolutionField)
+
+	if h.challengeUC == nil || middleware.HasAPIKey(c) {
+		return false, nil
+	}
+
+	err := h.challengeUC.Verify(c.Context(), input)
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, challenge.ErrChallengeRequired):
+		return true, c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewCodedErrorResponse(dto.ErrorCodeChallengeRequired, "Proof-of-work challenge is required"),
+		)
+	case errors.Is(err, challenge.ErrChallengeExpired):
+		return true, c.Status(fiber.StatusForbidden).JSON(
+			dto.NewCodedErrorResponse(dto.ErrorCodeChallengeExpired, "Challenge expired. Please fetch a new one and try again."),
+		)
+	default:
+		log.Printf("[Handler] Rejected proof-of-work: %v", err)
+		return true, c.Status(fiber.StatusForbidden).JSON(
+			dto.NewCodedErrorResponse(dto.ErrorCodeChallengeInvalid, "Proof-of-work verification failed"),
+		)
+	}
+}
+
+// submittedFields returns every field of a contact request body as strings
+func submittedFields(c *fiber.Ctx) map[string]string {
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationForm) {
+		fields := make(map[string]string)
+		c.Request().PostArgs().VisitAll(func(key, value []byte) {
+			fields[string(key)] = string(value)
+		})
+		return fields
+	}
+
+	var fields dto.FormRequest
+	if err := c.BodyParser(&fields); err != nil {
+		return map[string]string{}
+	}
+	return fields.Strings()
+}
+
+// clientInfo describes the client of a request for the submission history
+func clientInfo(c *fiber.Ctx) contact.ClientInfo {
+	origin := c.Get(fiber.HeaderOrigin)
+	if origin == "" {
+		origin = c.Get(fiber.HeaderReferer)
+	}
+
+	return contact.ClientInfo{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		Origin:    origin,
+	}
+}
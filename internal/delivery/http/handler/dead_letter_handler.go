@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/deadletter"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeadLetterHandler handles dead letter admin HTTP requests
+type DeadLetterHandler struct {
+	deadLetterUC deadletter.UseCase
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(deadLetterUC deadletter.UseCase) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterUC: deadLetterUC,
+	}
+}
+
+// List returns all undeliverable contacts
+// @Summary List dead letters
+// @Description Returns contacts whose delivery failed after all retries
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.DeadLetterListResponse
+// @Failure 401 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/dead-letters [get]
+func (h *DeadLetterHandler) List(c *fiber.Ctx) error {
+	letters, err := h.deadLetterUC.List(c.Context())
+	if err != nil {
+		log.Printf("[Handler] Failed to list dead letters: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to list dead letters"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewDeadLetterListResponse(letters),
+	)
+}
+
+// Redrive puts a dead letter back on the delivery queue
+// @Summary Re-drive dead letter
+// @Description Re-queues an undeliverable contact for another delivery attempt
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Submission ID"
+// @Success 202 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 503 {object} dto.Response
+// @Router /api/admin/dead-letters/{id}/redrive [post]
+func (h *DeadLetterHandler) Redrive(c *fiber.Ctx) error {
+	if err := h.deadLetterUC.Redrive(c.Context(), c.Params("id")); err != nil {
+		if errors.Is(err, deadletter.ErrDeadLetterNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(
+				dto.NewErrorResponse("Dead letter not found"),
+			)
+		}
+		log.Printf("[Handler] Failed to re-drive dead letter: %v", err)
+		return c.Status(fiber.StatusServiceUnavailable).JSON(
+			dto.NewErrorResponse("Failed to re-drive dead letter. Please try again later."),
+		)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(
+		dto.NewSuccessResponse("Dead letter queued for delivery"),
+	)
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/privacy"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PrivacyHandler handles data erasure admin HTTP requests
+type PrivacyHandler struct {
+	privacyUC privacy.UseCase
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(privacyUC privacy.UseCase) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyUC: privacyUC,
+	}
+}
+
+// Erase deletes everything stored about a submitter address. The address is
+// taken from the body rather than the URL so it doesn't end up in access logs.
+// @Summary Erase submitter data
+// @Description Deletes every submission, pending delivery and dead letter from an email address and lists what was removed
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ErasureRequest true "Submitter address"
+// @Success 200 {object} dto.ErasureResponse
+// @Failure 400 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/erasure [post]
+func (h *PrivacyHandler) Erase(c *fiber.Ctx) error {
+	var request dto.ErasureRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("Invalid request body"),
+		)
+	}
+
+	output, err := h.privacyUC.Erase(c.Context(), request.Email)
+	if err != nil {
+		if errors.Is(err, privacy.ErrEmailRequired) {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse(err.Error()),
+			)
+		}
+		log.Printf("[Handler] Failed to erase submitter data: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to erase submitter data"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewErasureResponse(output.Email, output.Submissions, output.Outbox),
+	)
+}
@@ -0,0 +1,279 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/submission"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// exportFlushEvery is how many exported rows are buffered before flushing to the client
+const exportFlushEvery = 100
+
+// SubmissionHandler handles submission history admin HTTP requests
+type SubmissionHandler struct {
+	submissionUC submission.UseCase
+}
+
+// NewSubmissionHandler creates a new submission handler
+func NewSubmissionHandler(submissionUC submission.UseCase) *SubmissionHandler {
+	return &SubmissionHandler{
+		submissionUC: submissionUC,
+	}
+}
+
+// List returns a page of stored submissions
+// @Summary List submissions
+// @Description Returns stored submissions, newest first, filtered by date range, form, status, email or text
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default 1)"
+// @Param per_page query int false "Submissions per page (default 20, max 100)"
+// @Param from query string false "Submitted on or after (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Submitted on or before (YYYY-MM-DD or RFC 3339)"
+// @Param form query string false "Form ID"
+// @Param status query string false "queued, sent, failed or quarantined"
+// @Param email query string false "Submitter email"
+// @Param q query string false "Text to search for in subject and message"
+// @Success 200 {object} dto.SubmissionListResponse
+// @Failure 400 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/submissions [get]
+func (h *SubmissionHandler) List(c *fiber.Ctx) error {
+	var query dto.SubmissionQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("Invalid query parameters"),
+		)
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse(err.Error()),
+		)
+	}
+
+	output, err := h.submissionUC.List(c.Context(), submission.ListInput{
+		Filter:  filter,
+		Page:    query.Page,
+		PerPage: query.PerPage,
+	})
+	if err != nil {
+		log.Printf("[Handler] Failed to list submissions: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to list submissions"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSubmissionListResponse(output.Submissions, output.Page, output.PerPage, output.Total),
+	)
+}
+
+// Get returns a single stored submission
+// @Summary Get submission
+// @Description Returns a stored submission with its client details and delivery status
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Submission ID"
+// @Success 200 {object} dto.SubmissionDetailResponse
+// @Failure 401 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 500 {object} dto.Response
+// @Router /api/admin/submissions/{id} [get]
+func (h *SubmissionHandler) Get(c *fiber.Ctx) error {
+	s, err := h.submissionUC.Get(c.Context(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, submission.ErrSubmissionNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(
+				dto.NewErrorResponse("Submission not found"),
+			)
+		}
+		log.Printf("[Handler] Failed to get submission: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to get submission"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSubmissionDetailResponse(s),
+	)
+}
+
+// Resend sends a stored submission again
+// @Summary Resend submission
+// @Description Sends a stored submission through the email provider again, optionally to another recipient, and records the attempt in its delivery history
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Submission ID"
+// @Param request body dto.ResendRequest false "Recipient override"
+// @Success 200 {object} dto.ResendResponse
+// @Failure 400 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Failure 404 {object} dto.Response
+// @Failure 422 {object} dto.Response
+// @Failure 502 {object} dto.ResendResponse
+// @Router /api/admin/submissions/{id}/resend [post]
+func (h *SubmissionHandler) Resend(c *fiber.Ctx) error {
+	var request dto.ResendRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(
+				dto.NewErrorResponse("Invalid request body"),
+			)
+		}
+	}
+
+	attempt, err := h.submissionUC.Resend(c.Context(), submission.ResendInput{
+		ID: c.Params("id"),
+		To: request.To,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, submission.ErrSubmissionNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(
+				dto.NewErrorResponse("Submission not found"),
+			)
+		case errors.Is(err, submission.ErrInvalidRecipient), errors.Is(err, submission.ErrSubmissionAnonymised):
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(
+				dto.NewErrorResponse(err.Error()),
+			)
+		case errors.Is(err, submission.ErrResendFailed):
+			return c.Status(fiber.StatusBadGateway).JSON(
+				dto.NewResendResponse(false, "Resend failed", attempt),
+			)
+		}
+		log.Printf("[Handler] Failed to resend submission: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(
+			dto.NewErrorResponse("Failed to resend submission"),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewResendResponse(true, "Submission resent", attempt),
+	)
+}
+
+// Export streams stored submissions as CSV or JSON Lines
+// @Summary Export submissions
+// @Description Streams submissions matching the filters, oldest first, as a CSV or JSON Lines download
+// @Tags admin
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Security BearerAuth
+// @Param format query string false "csv (default) or jsonl"
+// @Param from query string false "Submitted on or after (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Submitted on or before (YYYY-MM-DD or RFC 3339)"
+// @Param form query string false "Form ID"
+// @Param status query string false "queued, sent, failed or quarantined"
+// @Param email query string false "Submitter email"
+// @Param q query string false "Text to search for in subject and message"
+// @Success 200 {file} file
+// @Failure 400 {object} dto.Response
+// @Failure 401 {object} dto.Response
+// @Router /api/admin/submissions/export [get]
+func (h *SubmissionHandler) Export(c *fiber.Ctx) error {
+	var query dto.SubmissionQuery
+	if err := c.QueryParser(&query); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("Invalid query parameters"),
+		)
+	}
+
+	filter, err := query.Filter()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse(err.Error()),
+		)
+	}
+
+	format := c.Query("format", dto.ExportCSV)
+	switch format {
+	case dto.ExportCSV:
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	case dto.ExportJSONL:
+		c.Set(fiber.HeaderContentType, "application/x-ndjson")
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("format must be csv or jsonl"),
+		)
+	}
+
+	filename := fmt.Sprintf("submissions-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	c.Attachment(filename)
+
+	// The writer runs after the handler returns, so it must not use c
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		var err error
+		if format == dto.ExportCSV {
+			err = h.exportCSV(w, filter)
+		} else {
+			err = h.exportJSONL(w, filter)
+		}
+		if err != nil {
+			log.Printf("[Handler] Submission export aborted: %v", err)
+		}
+	})
+	return nil
+}
+
+// exportCSV writes the matching submissions to w as CSV
+func (h *SubmissionHandler) exportCSV(w *bufio.Writer, filter entity.SubmissionFilter) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(dto.SubmissionCSVHeader); err != nil {
+		return err
+	}
+
+	rows := 0
+	err := h.submissionUC.Export(context.Background(), filter, func(s *entity.Submission) error {
+		if err := cw.Write(dto.NewSubmissionCSVRecord(s)); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushEvery == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			return w.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportJSONL writes the matching submissions to w as JSON Lines
+func (h *SubmissionHandler) exportJSONL(w *bufio.Writer, filter entity.SubmissionFilter) error {
+	enc := json.NewEncoder(w)
+
+	rows := 0
+	return h.submissionUC.Export(context.Background(), filter, func(s *entity.Submission) error {
+		if err := enc.Encode(dto.NewSubmission(s)); err != nil {
+			return err
+		}
+		if rows++; rows%exportFlushEvery == 0 {
+			return w.Flush()
+		}
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminAuth protects admin endpoints with a static bearer token
+func AdminAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		provided := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+
+		if token == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(
+				dto.NewErrorResponse("Unauthorized"),
+			)
+		}
+
+		return c.Next()
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"log"
+	"net"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,26 +19,30 @@ func RequestLogger() fiber.Handler {
 		// Calculate duration
 		duration := time.Since(start)
 
-		// Get real IP (supports proxy)
-		ip := c.Get("X-Real-IP")
-		if ip == "" {
-			ip = c.Get("X-Forwarded-For")
-		}
-		if ip == "" {
-			ip = c.IP()
-		}
-
-		// Log request details
+		// Log request details. The client IP is truncated and the user agent
+		// left out, so request logs hold no personal data.
 		log.Printf(
-			"[HTTP] %s %s | %d | %v | IP: %s | UA: %s",
+			"[HTTP] %s %s | %d | %v | IP: %s",
 			c.Method(),
 			c.Path(),
 			c.Response().StatusCode(),
 			duration,
-			ip,
-			c.Get("User-Agent"),
+			truncateIP(c.IP()),
 		)
 
 		return err
 	}
 }
+
+// truncateIP zeroes the host part of an address: the last octet of IPv4 and
+// everything after the /48 prefix of IPv6
+func truncateIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "-"
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
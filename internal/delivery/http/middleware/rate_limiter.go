@@ -13,21 +13,26 @@ import (
 type RateLimiterConfig struct {
 	Max        int
 	Expiration time.Duration
+
+	// Next skips the limiter for requests it returns true for
+	Next func(c *fiber.Ctx) bool
+
+	// KeyGenerator groups requests into limits; defaults to the client IP
+	KeyGenerator func(c *fiber.Ctx) string
 }
 
 // NewRateLimiter creates a new rate limiting middleware
 func NewRateLimiter(cfg RateLimiterConfig) fiber.Handler {
+	keyGenerator := cfg.KeyGenerator
+	if keyGenerator == nil {
+		keyGenerator = clientIP
+	}
+
 	return limiter.New(limiter.Config{
-		Max:        cfg.Max,
-		Expiration: cfg.Expiration,
-		KeyGenerator: func(c *fiber.Ctx) string {
-			// Use X-Forwarded-For header if behind a proxy, otherwise use IP
-			forwarded := c.Get("X-Forwarded-For")
-			if forwarded != "" {
-				return forwarded
-			}
-			return c.IP()
-		},
+		Next:         cfg.Next,
+		Max:          cfg.Max,
+		Expiration:   cfg.Expiration,
+		KeyGenerator: keyGenerator,
 		LimitReached: func(c *fiber.Ctx) error {
 			return c.Status(fiber.StatusTooManyRequests).JSON(
 				dto.NewErrorResponse("Too many requests. Please try again later."),
@@ -38,6 +43,12 @@ func NewRateLimiter(cfg RateLimiterConfig) fiber.Handler {
 	})
 }
 
+// clientIP identifies the client for per-IP limits. Proxy headers are
+// only honoured for trusted proxies, as configured on the Fiber app.
+func clientIP(c *fiber.Ctx) string {
+	return c.IP()
+}
+
 // DefaultRateLimiter creates a rate limiter with default settings (10 req/min)
 func DefaultRateLimiter() fiber.Handler {
 	return NewRateLimiter(RateLimiterConfig{
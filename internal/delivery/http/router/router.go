@@ -6,6 +6,7 @@ import (
 	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/handler"
 	"github.com/andrianprasetya/go-mail-server/internal/delivery/http/middleware"
 	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+	"github.com/andrianprasetya/go-mail-server/internal/usecase/apikey"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -13,10 +14,16 @@ import (
 
 // Router holds all handlers and configuration for routing
 type Router struct {
-	app            *fiber.App
-	config         *config.Config
-	contactHandler *handler.ContactHandler
-	healthHandler  *handler.HealthHandler
+	app               *fiber.App
+	config            *config.Config
+	contactHandler    *handler.ContactHandler
+	healthHandler     *handler.HealthHandler
+	deadLetterHandler *handler.DeadLetterHandler
+	submissionHandler *handler.SubmissionHandler
+	privacyHandler    *handler.PrivacyHandler
+	apiKeyHandler     *handler.APIKeyHandler
+	classifierHandler *handler.ClassifierHandler
+	apiKeyUC          apikey.UseCase
 }
 
 // NewRouter creates a new router with all handlers
@@ -25,12 +32,24 @@ func NewRouter(
 	cfg *config.Config,
 	contactHandler *handler.ContactHandler,
 	healthHandler *handler.HealthHandler,
+	deadLetterHandler *handler.DeadLetterHandler,
+	submissionHandler *handler.SubmissionHandler,
+	privacyHandler *handler.PrivacyHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	classifierHandler *handler.ClassifierHandler,
+	apiKeyUC apikey.UseCase,
 ) *Router {
 	return &Router{
-		app:            app,
-		config:         cfg,
-		contactHandler: contactHandler,
-		healthHandler:  healthHandler,
+		app:               app,
+		config:            cfg,
+		contactHandler:    contactHandler,
+		healthHandler:     healthHandler,
+		deadLetterHandler: deadLetterHandler,
+		submissionHandler: submissionHandler,
+		privacyHandler:    privacyHandler,
+		apiKeyHandler:     apiKeyHandler,
+		classifierHandler: classifierHandler,
+		apiKeyUC:          apiKeyUC,
 	}
 }
 
@@ -58,12 +77,40 @@ func (r *Router) Setup() {
 	// API routes
 	api := r.app.Group("/api")
 
-	// Contact endpoint with rate limiting (default: 2 requests per 24 hours per IP)
+	// Contact endpoint with rate limiting (default: 2 requests per 24 hours per IP).
+	// Requests with a valid API key are limited per key instead; invalid keys
+	// count against the IP limit before they are rejected.
+	identifyAPIKey := middleware.IdentifyAPIKey(r.apiKeyUC)
+	apiKeyAuth := middleware.APIKeyAuth()
 	contactLimiter := middleware.NewRateLimiter(middleware.RateLimiterConfig{
 		Max:        r.config.RateLimit,
 		Expiration: time.Duration(r.config.RateLimitExpiration) * time.Hour,
+		Next:       middleware.HasAPIKey,
 	})
-	api.Post("/contact", contactLimiter, r.contactHandler.HandleContact)
+	api.Get("/contact/token", r.contactHandler.Token)
+	if r.config.PowEnabled {
+		api.Get("/challenge", r.contactHandler.Challenge)
+	}
+	api.Post("/contact", identifyAPIKey, contactLimiter, apiKeyAuth, r.contactHandler.HandleContact)
+	api.Post("/forms/:formID", identifyAPIKey, contactLimiter, apiKeyAuth, r.contactHandler.HandleForm)
+
+	// Admin endpoints (disabled unless ADMIN_TOKEN is set)
+	if r.config.AdminToken != "" {
+		admin := api.Group("/admin", middleware.AdminAuth(r.config.AdminToken))
+		admin.Get("/dead-letters", r.deadLetterHandler.List)
+		admin.Post("/dead-letters/:id/redrive", r.deadLetterHandler.Redrive)
+		admin.Get("/submissions", r.submissionHandler.List)
+		admin.Get("/submissions/export", r.submissionHandler.Export)
+		admin.Get("/submissions/:id", r.submissionHandler.Get)
+		admin.Post("/submissions/:id/resend", r.submissionHandler.Resend)
+		admin.Post("/submissions/:id/spam", r.classifierHandler.MarkSpam)
+		admin.Post("/submissions/:id/ham", r.classifierHandler.MarkHam)
+		admin.Get("/classifier", r.classifierHandler.Stats)
+		admin.Post("/erasure", r.privacyHandler.Erase)
+		admin.Get("/api-keys", r.apiKeyHandler.List)
+		admin.Post("/api-keys", r.apiKeyHandler.Create)
+		admin.Delete("/api-keys/:id", r.apiKeyHandler.Revoke)
+	}
 }
 
 // stringSliceToCSV converts a string slice to comma-separated string
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API key scopes
+const (
+	ScopeContact  = "contact" // POST /api/contact
+	ScopeAllForms = "form:*"  // POST /api/forms/:formID for every form
+	formScopeTag  = "form:"
+)
+
+// API key errors
+var (
+	ErrAPIKeyNameRequired = errors.New("api key name is required")
+	ErrAPIKeyNoScopes     = errors.New("api key needs at least one scope")
+	ErrAPIKeyScopeInvalid = errors.New("api key scope must be contact, form:* or form:<form id>")
+	ErrAPIKeyRateLimit    = errors.New("api key rate limit must be positive")
+)
+
+// APIKey lets a backend service submit without the per-IP limits (Domain Entity)
+type APIKey struct {
+	ID         string
+	Name       string
+	SecretHash string // hash of the secret part of the key; the key itself is never stored
+	Scopes     []string
+	RateLimit  int // requests per minute
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// FormScope returns the scope allowing submissions of a configured form
+func FormScope(formID string) string {
+	return formScopeTag + formID
+}
+
+// Allows reports whether the key grants the given scope
+func (k *APIKey) Allows(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope || (s == ScopeAllForms && strings.HasPrefix(scope, formScopeTag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate validates the key definition
+func (k *APIKey) Validate() error {
+	if strings.TrimSpace(k.Name) == "" {
+		return ErrAPIKeyNameRequired
+	}
+	if len(k.Scopes) == 0 {
+		return ErrAPIKeyNoScopes
+	}
+	for _, s := range k.Scopes {
+		if s != ScopeContact && (!strings.HasPrefix(s, formScopeTag) || s == formScopeTag) {
+			return fmt.Errorf("%w: %q", ErrAPIKeyScopeInvalid, s)
+		}
+	}
+	if k.RateLimit <= 0 {
+		return ErrAPIKeyRateLimit
+	}
+	return nil
+}
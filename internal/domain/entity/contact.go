@@ -4,14 +4,22 @@ import (
 	"errors"
 	"regexp"
 	"strings"
+	"time"
 )
 
 // Contact represents the contact form entity (Domain Entity)
 type Contact struct {
-	Name    string
-	Email   string
-	Subject string
-	Message string
+	ID          string
+	FormID      string // set for submissions of a configured form
+	Name        string
+	Email       string
+	Subject     string
+	Message     string
+	Fields      []FieldValue // all submitted values of a configured form
+	Recipients  Recipients   // overrides the default recipients when set
+	Route       string       // name of the routing rules that chose the recipients
+	Spam        *SpamScore   // content-based spam rating; nil when scoring is disabled
+	SubmittedAt time.Time
 }
 
 // Validation constants
@@ -38,6 +46,15 @@ var (
 // Email regex pattern (RFC 5322 simplified)
 var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 
+// embeddedEmailRegex finds addresses inside longer text
+var embeddedEmailRegex = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+
+// RedactAddresses replaces every email address in s, so provider errors that
+// quote a recipient can be logged without personal data
+func RedactAddresses(s string) string {
+	return embeddedEmailRegex.ReplaceAllString(s, "[address]")
+}
+
 // NewContact creates a new Contact entity with validation
 func NewContact(name, email, subject, message string) (*Contact, error) {
 	contact := &Contact{
@@ -93,3 +110,44 @@ func (c *Contact) Validate() error {
 
 	return nil
 }
+
+// Value returns a contact field by name for routing: name, email, subject,
+// message, form_id, email_domain or the name of a submitted form field
+func (c *Contact) Value(field string) string {
+	switch field {
+	case FieldNameName:
+		return c.Name
+	case FieldNameEmail:
+		return c.Email
+	case FieldNameSubject:
+		return c.Subject
+	case FieldNameMessage:
+		return c.Message
+	case FieldFormID:
+		return c.FormID
+	case FieldEmailDomain:
+		if i := strings.LastIndex(c.Email, "@"); i >= 0 {
+			return c.Email[i+1:]
+		}
+		return ""
+	}
+
+	for _, f := range c.Fields {
+		if f.Name == field {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// Anonymise removes everything the submitter wrote, keeping only what is needed
+// for statistics: ID, form, route, recipients and submission time
+func (c *Contact) Anonymise() {
+	c.Name = ""
+	c.Email = ""
+	c.Subject = ""
+	c.Message = ""
+	for i := range c.Fields {
+		c.Fields[i].Value = ""
+	}
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// DeadLetter represents a contact whose delivery failed after all retries
+type DeadLetter struct {
+	Contact  *Contact
+	Reason   string
+	Attempts int
+	FailedAt time.Time
+}
@@ -0,0 +1,286 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldType is the type of a form field
+type FieldType string
+
+// Supported field types
+const (
+	FieldString   FieldType = "string"
+	FieldEmail    FieldType = "email"
+	FieldPhone    FieldType = "phone"
+	FieldNumber   FieldType = "number"
+	FieldSelect   FieldType = "select"
+	FieldCheckbox FieldType = "checkbox"
+	FieldURL      FieldType = "url"
+)
+
+// Well-known field names mapped onto the contact's Name, Email, Subject and Message
+const (
+	FieldNameName    = "name"
+	FieldNameEmail   = "email"
+	FieldNameSubject = "subject"
+	FieldNameMessage = "message"
+)
+
+// Checkbox values stored on a submission
+const (
+	CheckboxChecked   = "yes"
+	CheckboxUnchecked = "no"
+)
+
+// Form definition errors
+var (
+	ErrFormIDInvalid = errors.New("form id must contain only lowercase letters, digits, '-' and '_'")
+	ErrFormNoFields  = errors.New("form must declare at least one field")
+)
+
+// formIDRegex restricts form IDs to URL-safe identifiers
+var formIDRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// phoneRegex accepts international numbers with common separators
+var phoneRegex = regexp.MustCompile(`^\+?[0-9][0-9 ()\-.]{5,19}$`)
+
+// Form is a configurable form definition (Domain Entity)
+type Form struct {
+	ID         string
+	Name       string
+	Recipients Recipients // overrides the default recipients when set
+	Fields     []FormField
+}
+
+// FormField declares a typed form field and its validation rules
+type FormField struct {
+	Name      string
+	Label     string
+	Type      FieldType
+	Required  bool
+	MinLength int
+	MaxLength int
+	Pattern   *regexp.Regexp
+	Options   []string // allowed values of a select field
+}
+
+// FieldValue is a submitted form field value, kept in declaration order
+type FieldValue struct {
+	Name  string
+	Label string
+	Value string
+}
+
+// FieldError is a validation error for a single form field
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// Validate validates the form definition
+func (f *Form) Validate() error {
+	if !formIDRegex.MatchString(f.ID) {
+		return ErrFormIDInvalid
+	}
+	if len(f.Fields) == 0 {
+		return ErrFormNoFields
+	}
+	if !f.Recipients.IsZero() {
+		if err := f.Recipients.Validate(); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool, len(f.Fields))
+	for _, field := range f.Fields {
+		if field.Name == "" {
+			return errors.New("field name is required")
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("field %s is declared twice", field.Name)
+		}
+		seen[field.Name] = true
+
+		switch field.Type {
+		case FieldString, FieldEmail, FieldPhone, FieldNumber, FieldCheckbox, FieldURL:
+		case FieldSelect:
+			if len(field.Options) == 0 {
+				return fmt.Errorf("select field %s must declare options", field.Name)
+			}
+		default:
+			return fmt.Errorf("field %s has unknown type %q", field.Name, field.Type)
+		}
+
+		if field.MaxLength > 0 && field.MinLength > field.MaxLength {
+			return fmt.Errorf("field %s min_length exceeds max_length", field.Name)
+		}
+	}
+
+	return nil
+}
+
+// NewContact validates submitted values against the form and creates a Contact.
+// Fields named name, email, subject and message fill the matching contact fields;
+// the subject defaults to the form name.
+func (f *Form) NewContact(values map[string]string) (*Contact, error) {
+	for name := range values {
+		if f.field(name) == nil {
+			return nil, &FieldError{Field: name, Message: "is not a field of this form"}
+		}
+	}
+
+	contact := &Contact{
+		FormID:     f.ID,
+		Subject:    f.Name,
+		Recipients: f.Recipients,
+	}
+
+	for _, field := range f.Fields {
+		value, err := field.normalize(values[field.Name])
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+
+		contact.Fields = append(contact.Fields, FieldValue{
+			Name:  field.Name,
+			Label: field.label(),
+			Value: value,
+		})
+
+		switch {
+		case field.Name == FieldNameName:
+			contact.Name = value
+		case field.Name == FieldNameSubject:
+			contact.Subject = value
+		case field.Name == FieldNameMessage:
+			contact.Message = value
+		case field.Type == FieldEmail && (field.Name == FieldNameEmail || contact.Email == ""):
+			contact.Email = value
+		}
+	}
+
+	return contact, nil
+}
+
+// field returns the field with the given name, or nil
+func (f *Form) field(name string) *FormField {
+	for i := range f.Fields {
+		if f.Fields[i].Name == name {
+			return &f.Fields[i]
+		}
+	}
+	return nil
+}
+
+// label returns the display label of the field
+func (f *FormField) label() string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Name
+}
+
+// normalize validates a submitted value and returns it in canonical form;
+// an empty result means the optional field was left blank
+func (f *FormField) normalize(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+
+	if f.Type == FieldCheckbox {
+		checked, err := parseCheckbox(value)
+		if err != nil {
+			return "", f.fail("must be checked or unchecked")
+		}
+		if f.Required && !checked {
+			return "", f.fail("must be checked")
+		}
+		if checked {
+			return CheckboxChecked, nil
+		}
+		return CheckboxUnchecked, nil
+	}
+
+	if value == "" {
+		if f.Required {
+			return "", f.fail("is required")
+		}
+		return "", nil
+	}
+
+	length := utf8.RuneCountInString(value)
+	if f.MinLength > 0 && length < f.MinLength {
+		return "", f.fail(fmt.Sprintf("must be at least %d characters", f.MinLength))
+	}
+	if f.MaxLength > 0 && length > f.MaxLength {
+		return "", f.fail(fmt.Sprintf("must be at most %d characters", f.MaxLength))
+	}
+
+	switch f.Type {
+	case FieldEmail:
+		if len(value) > MaxEmailLength || !emailRegex.MatchString(value) {
+			return "", f.fail("must be a valid email address")
+		}
+	case FieldPhone:
+		if !phoneRegex.MatchString(value) {
+			return "", f.fail("must be a valid phone number")
+		}
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", f.fail("must be a number")
+		}
+	case FieldURL:
+		u, err := url.ParseRequestURI(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", f.fail("must be a valid http(s) URL")
+		}
+	case FieldSelect:
+		if !contains(f.Options, value) {
+			return "", f.fail("must be one of " + strings.Join(f.Options, ", "))
+		}
+	}
+
+	if f.Pattern != nil && !f.Pattern.MatchString(value) {
+		return "", f.fail("has an invalid format")
+	}
+
+	return value, nil
+}
+
+// fail returns a validation error for the field
+func (f *FormField) fail(message string) error {
+	return &FieldError{Field: f.Name, Message: message}
+}
+
+// parseCheckbox interprets the values browsers and JSON clients send for a checkbox
+func parseCheckbox(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "false", "off", "no", "0":
+		return false, nil
+	case "true", "on", "yes", "1":
+		return true, nil
+	}
+	return false, errors.New("invalid checkbox value")
+}
+
+// contains reports whether values contains s
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Recipient errors
+var (
+	ErrRecipientsNoTo      = errors.New("at least one To recipient is required")
+	ErrRecipientEmailValid = errors.New("recipient email format is invalid")
+)
+
+// Recipients are the To, CC and BCC addresses a notification is sent to
+type Recipients struct {
+	To  []string
+	CC  []string
+	BCC []string
+}
+
+// NewRecipients creates recipients from address lists, trimming blanks and
+// dropping addresses already listed earlier (To before CC before BCC)
+func NewRecipients(to, cc, bcc []string) Recipients {
+	seen := make(map[string]bool)
+	clean := func(addresses []string) []string {
+		var out []string
+		for _, a := range addresses {
+			a = strings.TrimSpace(a)
+			key := strings.ToLower(a)
+			if a == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, a)
+		}
+		return out
+	}
+
+	return Recipients{
+		To:  clean(to),
+		CC:  clean(cc),
+		BCC: clean(bcc),
+	}
+}
+
+// IsZero reports whether no recipients are set
+func (r Recipients) IsZero() bool {
+	return len(r.To) == 0 && len(r.CC) == 0 && len(r.BCC) == 0
+}
+
+// Validate checks that there is a To recipient and every address is well-formed
+func (r Recipients) Validate() error {
+	if len(r.To) == 0 {
+		return ErrRecipientsNoTo
+	}
+	return r.validateAddresses()
+}
+
+// validateAddresses checks that every address is well-formed
+func (r Recipients) validateAddresses() error {
+	for _, list := range [][]string{r.To, r.CC, r.BCC} {
+		for _, address := range list {
+			if len(address) > MaxEmailLength || !emailRegex.MatchString(address) {
+				return fmt.Errorf("%w: %q", ErrRecipientEmailValid, address)
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns the recipients for logging
+func (r Recipients) String() string {
+	s := strings.Join(r.To, ", ")
+	if len(r.CC) > 0 {
+		s += " cc " + strings.Join(r.CC, ", ")
+	}
+	if len(r.BCC) > 0 {
+		s += fmt.Sprintf(" (+%d bcc)", len(r.BCC))
+	}
+	return s
+}
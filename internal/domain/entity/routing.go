@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Condition operators
+const (
+	OpEquals   = "equals"
+	OpContains = "contains"
+	OpMatches  = "matches"
+	OpIn       = "in"
+)
+
+// Derived contact fields available to routing conditions
+const (
+	FieldFormID      = "form_id"
+	FieldEmailDomain = "email_domain"
+)
+
+// DefaultRouteName is the route name used when no terminal rule matches
+const DefaultRouteName = "default"
+
+// Routing rule errors
+var (
+	ErrRuleNameRequired  = errors.New("rule name is required")
+	ErrRuleNoRecipients  = errors.New("rule must declare recipients")
+	ErrConditionNoField  = errors.New("condition field is required")
+	ErrConditionOperator = errors.New("unknown condition operator")
+)
+
+// RoutingTable holds ordered routing rules and the fallback recipients (Domain Entity)
+type RoutingTable struct {
+	Rules   []RoutingRule
+	Default Recipients // used when no terminal rule matches and the form has no recipients
+}
+
+// RoutingRule sends matching contacts to its recipients. A terminal rule
+// replaces the recipients and stops evaluation; a Continue rule adds its
+// recipients and lets later rules be evaluated too.
+type RoutingRule struct {
+	Name       string
+	Conditions []Condition // all must match; none matches every contact
+	Recipients Recipients
+	Continue   bool
+}
+
+// Condition matches a contact field against a value
+type Condition struct {
+	Field   string // name, email, subject, message, form_id, email_domain or a form field
+	Op      string
+	Value   string
+	Values  []string       // for OpIn
+	Pattern *regexp.Regexp // for OpMatches
+}
+
+// Route is the outcome of routing a contact
+type Route struct {
+	Name       string
+	Recipients Recipients
+}
+
+// Route evaluates the rules in order and returns the recipients for the contact
+func (t *RoutingTable) Route(contact *Contact) Route {
+	var names []string
+	var additions []Recipients
+	var base Recipients
+	matched := false
+
+	for _, rule := range t.Rules {
+		if !rule.Matches(contact) {
+			continue
+		}
+
+		names = append(names, rule.Name)
+		if rule.Continue {
+			additions = append(additions, rule.Recipients)
+			continue
+		}
+
+		base = rule.Recipients
+		matched = true
+		break
+	}
+
+	if !matched {
+		names = append(names, DefaultRouteName)
+		base = t.Default
+		if !contact.Recipients.IsZero() {
+			base = contact.Recipients
+		}
+	}
+
+	// Copy so appending never writes into a rule's recipients
+	to := append([]string{}, base.To...)
+	cc := append([]string{}, base.CC...)
+	bcc := append([]string{}, base.BCC...)
+	for _, add := range additions {
+		to = append(to, add.To...)
+		cc = append(cc, add.CC...)
+		bcc = append(bcc, add.BCC...)
+	}
+
+	return Route{
+		Name:       strings.Join(names, "+"),
+		Recipients: NewRecipients(to, cc, bcc),
+	}
+}
+
+// Validate validates the routing rules
+func (t *RoutingTable) Validate() error {
+	for _, rule := range t.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the rule definition
+func (r *RoutingRule) Validate() error {
+	if r.Name == "" {
+		return ErrRuleNameRequired
+	}
+	if r.Recipients.IsZero() {
+		return ErrRuleNoRecipients
+	}
+	// Continue rules add to another route, so they don't need a To recipient
+	if r.Continue {
+		if err := r.Recipients.validateAddresses(); err != nil {
+			return err
+		}
+	} else if err := r.Recipients.Validate(); err != nil {
+		return err
+	}
+
+	for _, c := range r.Conditions {
+		if c.Field == "" {
+			return ErrConditionNoField
+		}
+		switch c.Op {
+		case OpEquals, OpContains, OpIn:
+		case OpMatches:
+			if c.Pattern == nil {
+				return fmt.Errorf("condition on %s needs a pattern", c.Field)
+			}
+		default:
+			return fmt.Errorf("%w %q", ErrConditionOperator, c.Op)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether every condition of the rule matches the contact
+func (r *RoutingRule) Matches(contact *Contact) bool {
+	for _, c := range r.Conditions {
+		if !c.Matches(contact) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether the condition matches the contact; text
+// comparisons are case-insensitive, patterns are used as written
+func (c *Condition) Matches(contact *Contact) bool {
+	value := contact.Value(c.Field)
+
+	switch c.Op {
+	case OpEquals:
+		return strings.EqualFold(value, c.Value)
+	case OpContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.Value))
+	case OpMatches:
+		return c.Pattern.MatchString(value)
+	case OpIn:
+		for _, v := range c.Values {
+			if strings.EqualFold(value, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
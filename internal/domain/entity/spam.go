@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpamAction is what happens to a submission because of its spam score
+type SpamAction string
+
+// Spam actions, from least to most severe
+const (
+	SpamActionNone       SpamAction = ""
+	SpamActionTag        SpamAction = "tag"        // delivered with SpamSubjectTag in the subject
+	SpamActionQuarantine SpamAction = "quarantine" // stored but not delivered
+	SpamActionReject     SpamAction = "reject"     // refused
+)
+
+// SpamSubjectTag prefixes the notification subject of tagged submissions
+const SpamSubjectTag = "[SPAM?]"
+
+// SpamRuleScore is the contribution of one scorer to a spam score
+type SpamRuleScore struct {
+	Rule   string
+	Points float64
+	Detail string // why the points were given; never contains submitted text
+}
+
+// SpamScore is the content-based spam rating of a contact
+type SpamScore struct {
+	Total  float64
+	Rules  []SpamRuleScore // scorers that gave points
+	Action SpamAction
+}
+
+// Add records points given by a scorer
+func (s *SpamScore) Add(rule string, points float64, detail string) {
+	if points == 0 {
+		return
+	}
+	s.Total += points
+	s.Rules = append(s.Rules, SpamRuleScore{Rule: rule, Points: points, Detail: detail})
+}
+
+// String returns the score with its breakdown, e.g. "5.0 (links=2.0, tlds=3.0)"
+func (s *SpamScore) String() string {
+	if len(s.Rules) == 0 {
+		return fmt.Sprintf("%.1f", s.Total)
+	}
+
+	parts := make([]string, 0, len(s.Rules))
+	for _, r := range s.Rules {
+		parts = append(parts, fmt.Sprintf("%s=%.1f", r.Rule, r.Points))
+	}
+	return fmt.Sprintf("%.1f (%s)", s.Total, strings.Join(parts, ", "))
+}
+
+// SpamThresholds are the scores at which each action applies. Zero disables an action.
+type SpamThresholds struct {
+	Tag        float64
+	Quarantine float64
+	Reject     float64
+}
+
+// Action returns the most severe action whose threshold the total reaches
+func (t SpamThresholds) Action(total float64) SpamAction {
+	switch {
+	case t.Reject > 0 && total >= t.Reject:
+		return SpamActionReject
+	case t.Quarantine > 0 && total >= t.Quarantine:
+		return SpamActionQuarantine
+	case t.Tag > 0 && total >= t.Tag:
+		return SpamActionTag
+	}
+	return SpamActionNone
+}
+
+// SpamLabel is an admin's verdict on a submission, used to train the classifier
+type SpamLabel string
+
+// Spam labels
+const (
+	SpamLabelNone SpamLabel = ""
+	SpamLabelSpam SpamLabel = "spam"
+	SpamLabelHam  SpamLabel = "ham" // wanted mail
+)
+
+// ClassifierStats summarises what the spam classifier has learned
+type ClassifierStats struct {
+	SpamMessages int
+	HamMessages  int
+	Tokens       int // distinct tokens seen
+}
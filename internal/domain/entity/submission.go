@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// SubmissionStatus is the delivery status of a stored submission
+type SubmissionStatus string
+
+// Submission statuses
+const (
+	SubmissionQueued SubmissionStatus = "queued"
+	SubmissionSent   SubmissionStatus = "sent"
+	SubmissionFailed SubmissionStatus = "failed"
+
+	// SubmissionQuarantined is a submission held back by its spam score
+	SubmissionQuarantined SubmissionStatus = "quarantined"
+)
+
+// anonymisedError replaces delivery errors of an anonymised submission; provider
+// errors can quote the submitter's address or message, but a failed attempt
+// must still read as failed
+const anonymisedError = "delivery failed (details removed)"
+
+// Submission is a stored record of an accepted contact (Domain Entity)
+type Submission struct {
+	Contact    *Contact
+	ClientIP   string
+	UserAgent  string
+	Origin     string
+	Status     SubmissionStatus
+	Provider   string // provider that delivered the notification
+	MessageID  string // message ID assigned by the provider
+	Attempts   int
+	Reason     string // last delivery error
+	History    []DeliveryAttempt
+	Label      SpamLabel // admin verdict the classifier was trained with
+	Anonymised bool      // personal data was removed by the retention policy
+	UpdatedAt  time.Time
+}
+
+// Anonymise removes the submitter's personal data from the submission
+func (s *Submission) Anonymise() {
+	s.Contact.Anonymise()
+	s.ClientIP = ""
+	s.UserAgent = ""
+	if s.Reason != "" {
+		s.Reason = anonymisedError
+	}
+	for i := range s.History {
+		if s.History[i].Error != "" {
+			s.History[i].Error = anonymisedError
+		}
+	}
+	s.Anonymised = true
+}
+
+// DeliveryAttempt is one attempt to send a submission's notification
+type DeliveryAttempt struct {
+	At         time.Time
+	Resend     bool // requested by an admin rather than made by the delivery queue
+	Recipients Recipients
+	Provider   string
+	MessageID  string
+	Error      string // empty when the attempt succeeded
+}
+
+// NewDeliveryAttempt records the outcome of sending to recipients
+func NewDeliveryAttempt(recipients Recipients, receipt *DeliveryReceipt, err error) DeliveryAttempt {
+	attempt := DeliveryAttempt{
+		At:         time.Now().UTC(),
+		Recipients: recipients,
+	}
+	if receipt != nil {
+		attempt.Provider = receipt.Provider
+		attempt.MessageID = receipt.MessageID
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	return attempt
+}
+
+// Succeeded reports whether the attempt delivered the notification
+func (a DeliveryAttempt) Succeeded() bool {
+	return a.Error == ""
+}
+
+// DeliveryReceipt identifies a message accepted by an email provider
+type DeliveryReceipt struct {
+	Provider  string
+	MessageID string
+}
+
+// SubmissionFilter selects submissions from the history. Zero fields don't filter.
+type SubmissionFilter struct {
+	Since  time.Time // submitted at or after
+	Until  time.Time // submitted before
+	FormID string
+	Status SubmissionStatus
+	Email  string // submitter address, case-insensitive
+	Query  string // text searched for in subject and message, case-insensitive
+}
+
+// Matches reports whether the submission passes every filter
+func (f SubmissionFilter) Matches(s *Submission) bool {
+	c := s.Contact
+
+	if !f.Since.IsZero() && c.SubmittedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !c.SubmittedAt.Before(f.Until) {
+		return false
+	}
+	if f.FormID != "" && c.FormID != f.FormID {
+		return false
+	}
+	if f.Status != "" && s.Status != f.Status {
+		return false
+	}
+	if f.Email != "" && !strings.EqualFold(c.Email, f.Email) {
+		return false
+	}
+	if f.Query != "" {
+		q := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(c.Subject), q) && !strings.Contains(strings.ToLower(c.Message), q) {
+			return false
+		}
+	}
+
+	return true
+}
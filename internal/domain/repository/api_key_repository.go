@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// APIKeyRepository defines the interface for API key storage (Domain Layer)
+type APIKeyRepository interface {
+	// Create stores a new API key
+	Create(key *entity.APIKey) error
+
+	// Get returns an API key by ID, or ErrNotFound
+	Get(id string) (*entity.APIKey, error)
+
+	// List returns all API keys, oldest first
+	List() ([]*entity.APIKey, error)
+
+	// Touch records that a key was used at the given time
+	Touch(id string, at time.Time) error
+
+	// Delete removes an API key
+	Delete(id string) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrCaptchaRejected is returned when the provider rejects a CAPTCHA response
+// (invalid, expired or already used token, or a score below the threshold)
+var ErrCaptchaRejected = errors.New("captcha rejected")
+
+// CaptchaVerifier verifies CAPTCHA responses with the provider (Domain Layer)
+// This interface is implemented by infrastructure layer (hCaptcha, reCAPTCHA, Turnstile)
+type CaptchaVerifier interface {
+	// Verify checks a CAPTCHA response token. It returns ErrCaptchaRejected when
+	// the provider rejects it; other errors mean the check could not be made.
+	Verify(ctx context.Context, token, remoteIP string) error
+
+	// ResponseField is the form field the provider's widget submits the token in
+	ResponseField() string
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// DeadLetterRepository defines the interface for undeliverable contacts (Domain Layer)
+type DeadLetterRepository interface {
+	// List returns all dead letters, oldest first
+	List() ([]*entity.DeadLetter, error)
+
+	// Requeue moves a dead letter back to pending and returns it as it was
+	// before, so it can be restored if it can't be queued
+	Requeue(id string) (*entity.DeadLetter, error)
+
+	// DeleteBefore removes dead letters submitted before the given time and
+	// returns how many were removed
+	DeleteBefore(before time.Time) (int, error)
+}
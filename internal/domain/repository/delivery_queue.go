@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// Delivery queue errors
+var (
+	ErrQueueFull   = errors.New("delivery queue is full")
+	ErrQueueClosed = errors.New("delivery queue is closed")
+)
+
+// DeliveryQueue defines the interface for asynchronous email delivery (Domain Layer)
+// This interface is implemented by infrastructure layer (in-process worker pool, etc.)
+type DeliveryQueue interface {
+	// Enqueue schedules a validated contact for delivery
+	Enqueue(contact *entity.Contact) error
+}
@@ -6,5 +6,8 @@ import "github.com/andrianprasetya/go-mail-server/internal/domain/entity"
 // This interface is implemented by infrastructure layer (SMTP, SendGrid, etc.)
 type EmailRepository interface {
 	// Send sends an email based on contact information
-	Send(contact *entity.Contact) error
+	Send(contact *entity.Contact) (*entity.DeliveryReceipt, error)
+
+	// SendConfirmation sends the submitter a confirmation with a copy of their message
+	SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error)
 }
@@ -0,0 +1,18 @@
+package repository
+
+import "errors"
+
+// Repository errors shared by all implementations
+var (
+	// ErrNotFound is returned when a requested record does not exist
+	ErrNotFound = errors.New("record not found")
+
+	// ErrPermanentFailure marks a delivery error that will not succeed on retry
+	// (e.g. SMTP 5xx replies, rejected recipients, invalid credentials)
+	ErrPermanentFailure = errors.New("permanent delivery failure")
+)
+
+// IsPermanent reports whether a delivery error should not be retried
+func IsPermanent(err error) bool {
+	return errors.Is(err, ErrPermanentFailure)
+}
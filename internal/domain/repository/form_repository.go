@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+// FormRepository defines the interface for looking up form definitions (Domain Layer)
+type FormRepository interface {
+	// Get returns the form with the given ID, or ErrNotFound
+	Get(id string) (*entity.Form, error)
+
+	// List returns every configured form
+	List() []*entity.Form
+}
@@ -0,0 +1,26 @@
+package repository
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+// OutboxRepository defines the interface for durable delivery tracking (Domain Layer)
+// Contacts are recorded before delivery so they survive process restarts
+type OutboxRepository interface {
+	// Add records a contact that is about to be delivered
+	Add(contact *entity.Contact) error
+
+	// MarkSent marks a contact as delivered
+	MarkSent(id string) error
+
+	// MarkFailed moves a contact to the dead letters after the given number of attempts
+	MarkFailed(id string, reason string, attempts int) error
+
+	// Remove drops a contact that will not be delivered (e.g. rejected at enqueue)
+	Remove(id string) error
+
+	// Pending returns recorded contacts that were neither sent nor failed
+	Pending() ([]*entity.Contact, error)
+
+	// DeleteByEmail removes pending contacts and dead letters from the given
+	// submitter address (case-insensitive) and returns their IDs
+	DeleteByEmail(email string) ([]string, error)
+}
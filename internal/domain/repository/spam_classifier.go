@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+// SpamClassifier learns from labelled contacts to rate new ones (Domain Layer)
+// This interface is implemented by infrastructure layer (naive Bayes, etc.)
+type SpamClassifier interface {
+	// Learn trains the classifier with a contact known to be spam or not
+	Learn(contact *entity.Contact, spam bool)
+
+	// Unlearn reverses an earlier Learn of the same contact and verdict
+	Unlearn(contact *entity.Contact, spam bool)
+
+	// SpamProbability returns how likely the contact is spam, from 0 to 1;
+	// 0.5 when nothing is known about it
+	SpamProbability(contact *entity.Contact) float64
+
+	// Stats returns what the classifier has learned
+	Stats() entity.ClassifierStats
+
+	// Save persists what the classifier has learned
+	Save() error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+// SpamScorer rates one aspect of a contact for spam (Domain Layer)
+// This interface is implemented by infrastructure layer (link count, keywords, etc.)
+type SpamScorer interface {
+	// Name identifies the scorer in score breakdowns
+	Name() string
+
+	// Score returns the points the contact earns, 0 when it looks fine, and a
+	// detail explaining them that never repeats submitted text
+	Score(contact *entity.Contact) (points float64, detail string)
+}
+
+// SpamRecorder is implemented by scorers that learn from accepted contacts,
+// such as the duplicate scorer. Score only checks; Record is called once the
+// contact has been stored, so rejected or failed submissions aren't counted.
+type SpamRecorder interface {
+	// Record remembers an accepted contact
+	Record(contact *entity.Contact)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// SubmissionRepository defines the interface for the submission history (Domain Layer)
+type SubmissionRepository interface {
+	// Create stores a newly accepted submission
+	Create(submission *entity.Submission) error
+
+	// Get returns a submission by ID, or ErrNotFound
+	Get(id string) (*entity.Submission, error)
+
+	// List returns the submissions matching filter, newest first, skipping
+	// offset matches and returning at most limit, plus the total number of matches
+	List(filter entity.SubmissionFilter, offset, limit int) ([]*entity.Submission, int, error)
+
+	// Each calls fn for every submission matching filter, oldest first, without
+	// loading them all into memory; an error from fn stops the iteration
+	Each(filter entity.SubmissionFilter, fn func(*entity.Submission) error) error
+
+	// MarkSent records the successful delivery of a submission
+	MarkSent(id string, receipt *entity.DeliveryReceipt, attempts int) error
+
+	// AddAttempt appends a delivery attempt to the submission's history
+	AddAttempt(id string, attempt entity.DeliveryAttempt) error
+
+	// MarkFailed records that delivery of a submission was given up
+	MarkFailed(id string, reason string, attempts int) error
+
+	// SetLabel records the spam label a submission was trained with
+	SetLabel(id string, label entity.SpamLabel) error
+
+	// Delete removes a submission
+	Delete(id string) error
+
+	// DeleteByEmail removes every submission from the given submitter address
+	// (case-insensitive) and returns the removed submissions
+	DeleteByEmail(email string) ([]*entity.Submission, error)
+
+	// DeleteBefore removes submissions submitted before the given time and
+	// returns how many were removed
+	DeleteBefore(before time.Time) (int, error)
+
+	// AnonymiseBefore anonymises submissions submitted before the given time
+	// and returns how many were changed; anonymised ones are not counted again
+	AnonymiseBefore(before time.Time) (int, error)
+}
@@ -0,0 +1,167 @@
+package apikeys
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketName is the bucket holding API keys keyed by ID
+var bucketName = []byte("api_keys")
+
+// record is the persisted form of an API key
+type record struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	SecretHash string    `json:"secret_hash"`
+	Scopes     []string  `json:"scopes"`
+	RateLimit  int       `json:"rate_limit"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at,omitempty"`
+}
+
+// boltAPIKeys implements APIKeyRepository using an embedded bbolt database
+type boltAPIKeys struct {
+	db *bolt.DB
+}
+
+// NewBoltAPIKeys creates a new API key repository backed by db
+func NewBoltAPIKeys(db *bolt.DB) (repository.APIKeyRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api keys bucket: %w", err)
+	}
+
+	return &boltAPIKeys{db: db}, nil
+}
+
+// Create stores a new API key
+func (r *boltAPIKeys) Create(key *entity.APIKey) error {
+	rec := &record{
+		ID:         key.ID,
+		Name:       key.Name,
+		SecretHash: key.SecretHash,
+		Scopes:     key.Scopes,
+		RateLimit:  key.RateLimit,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+
+	return r.db.Update(func(tx *bolt.Tx) error {
+		return put(tx.Bucket(bucketName), rec)
+	})
+}
+
+// Get returns an API key by ID
+func (r *boltAPIKeys) Get(id string) (*entity.APIKey, error) {
+	var key *entity.APIKey
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		rec, err := get(tx.Bucket(bucketName), id)
+		if err != nil {
+			return err
+		}
+		key = rec.toAPIKey()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// List returns all API keys, oldest first
+func (r *boltAPIKeys) List() ([]*entity.APIKey, error) {
+	var keys []*entity.APIKey
+
+	err := r.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).ForEach(func(_, v []byte) error {
+			var rec record
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			keys = append(keys, rec.toAPIKey())
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read api keys: %w", err)
+	}
+
+	// IDs are random, so order by creation time
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+	return keys, nil
+}
+
+// Touch records that a key was used at the given time
+func (r *boltAPIKeys) Touch(id string, at time.Time) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		rec, err := get(b, id)
+		if err != nil {
+			return err
+		}
+
+		rec.LastUsedAt = at
+		return put(b, rec)
+	})
+}
+
+// Delete removes an API key
+func (r *boltAPIKeys) Delete(id string) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		if b.Get([]byte(id)) == nil {
+			return fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+// toAPIKey converts a record back into a domain entity
+func (r *record) toAPIKey() *entity.APIKey {
+	return &entity.APIKey{
+		ID:         r.ID,
+		Name:       r.Name,
+		SecretHash: r.SecretHash,
+		Scopes:     r.Scopes,
+		RateLimit:  r.RateLimit,
+		CreatedAt:  r.CreatedAt,
+		LastUsedAt: r.LastUsedAt,
+	}
+}
+
+// get loads a record by ID
+func get(b *bolt.Bucket, id string) (*record, error) {
+	v := b.Get([]byte(id))
+	if v == nil {
+		return nil, fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+	}
+
+	var rec record
+	if err := json.Unmarshal(v, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// put stores a record under its ID
+func put(b *bolt.Bucket, rec *record) error {
+	v, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(rec.ID), v)
+}
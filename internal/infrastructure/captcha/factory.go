@@ -0,0 +1,21 @@
+package captcha
+
+import (
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// NewCaptchaVerifier creates the verifier selected by CAPTCHA_PROVIDER, or nil
+// when CAPTCHA checks are disabled
+func NewCaptchaVerifier(cfg *config.Config) repository.CaptchaVerifier {
+	switch cfg.CaptchaProvider {
+	case config.CaptchaHCaptcha:
+		return NewHCaptchaVerifier(cfg.CaptchaSecret, cfg.CaptchaVerifyURL)
+	case config.CaptchaReCaptcha:
+		return NewReCaptchaVerifier(cfg.CaptchaSecret, cfg.CaptchaVerifyURL, cfg.CaptchaMinScore)
+	case config.CaptchaTurnstile:
+		return NewTurnstileVerifier(cfg.CaptchaSecret, cfg.CaptchaVerifyURL)
+	default:
+		return nil
+	}
+}
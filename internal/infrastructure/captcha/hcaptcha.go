@@ -0,0 +1,15 @@
+package captcha
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+// hcaptchaEndpoint is the hCaptcha verification API
+const hcaptchaEndpoint = "https://api.hcaptcha.com/siteverify"
+
+// hcaptchaField is the form field the hCaptcha widget submits its token in
+const hcaptchaField = "h-captcha-response"
+
+// NewHCaptchaVerifier creates an hCaptcha verifier. An empty endpoint uses the
+// hCaptcha API.
+func NewHCaptchaVerifier(secret, endpoint string) repository.CaptchaVerifier {
+	return newSiteVerifier("hCaptcha", endpoint, hcaptchaEndpoint, secret, hcaptchaField, 0)
+}
@@ -0,0 +1,16 @@
+package captcha
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+// recaptchaEndpoint is the Google reCAPTCHA verification API
+const recaptchaEndpoint = "https://www.google.com/recaptcha/api/siteverify"
+
+// recaptchaField is the form field the reCAPTCHA widget submits its token in
+const recaptchaField = "g-recaptcha-response"
+
+// NewReCaptchaVerifier creates a Google reCAPTCHA verifier. v2 responses pass
+// on success alone; v3 responses must also score at least minScore. An empty
+// endpoint uses the Google API.
+func NewReCaptchaVerifier(secret, endpoint string, minScore float64) repository.CaptchaVerifier {
+	return newSiteVerifier("reCAPTCHA", endpoint, recaptchaEndpoint, secret, recaptchaField, minScore)
+}
@@ -0,0 +1,15 @@
+package captcha
+
+import "github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+// turnstileEndpoint is the Cloudflare Turnstile verification API
+const turnstileEndpoint = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+
+// turnstileField is the form field the Turnstile widget submits its token in
+const turnstileField = "cf-turnstile-response"
+
+// NewTurnstileVerifier creates a Cloudflare Turnstile verifier. An empty
+// endpoint uses the Cloudflare API.
+func NewTurnstileVerifier(secret, endpoint string) repository.CaptchaVerifier {
+	return newSiteVerifier("Turnstile", endpoint, turnstileEndpoint, secret, turnstileField, 0)
+}
@@ -2,19 +2,25 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
 	"github.com/joho/godotenv"
 )
 
 // Config holds all configuration for the application
 type Config struct {
 	// Server
-	AppPort string
-	AppEnv  string
+	AppPort        string
+	AppEnv         string
+	ProxyHeader    string   // header holding the client IP when behind a reverse proxy; empty uses the peer address
+	TrustedProxies []string // proxy IPs or CIDR ranges whose ProxyHeader is believed
 
 	// SMTP
 	SMTPHost     string
@@ -24,7 +30,21 @@ type Config struct {
 	SMTPEmail    string // sender "From" email address
 
 	// Email
-	ReceiverEmail string
+	ReceiverEmail string   // comma-separated To addresses
+	ReceiverCC    []string // RECEIVER_CC, comma-separated
+	ReceiverBCC   []string // RECEIVER_BCC, comma-separated
+	TemplateDir   string   // overrides the built-in templates; reloaded on change
+	FormsFile     string   // JSON or YAML form definitions served at /api/forms/:formID
+	RoutingFile   string   // JSON or YAML routing rules choosing recipients per submission
+
+	// Auto-reply to the submitter
+	AutoReplyEnabled  bool
+	AutoReplyInterval int // in minutes, per recipient address
+
+	// Providers in failover order; defaults to a single provider built from SMTP_*
+	Providers               []ProviderConfig
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  int // in seconds
 
 	// CORS
 	AllowedOrigins []string
@@ -32,14 +52,120 @@ type Config struct {
 	// Rate Limiting
 	RateLimit           int
 	RateLimitExpiration int // in hours
+	APIKeyRateLimit     int // default requests per minute for new API keys
+
+	// Spam checks
+	HoneypotField    string // hidden form field that must stay empty; empty disables
+	MinSubmitSeconds int    // minimum seconds between GET /api/contact/token and submitting; 0 disables
+	FormTokenSecret  string // signs form tokens; random per process when empty
+	FormTokenMaxAge  int    // in hours
+	SpamScoringFile  string // JSON or YAML content scorers and thresholds; empty disables
+	SpamBayesFile    string // trained spam classifier; defaults to bayes.json in DataDir
+
+	// CAPTCHA verification
+	CaptchaProvider  string  // hcaptcha | recaptcha | turnstile; empty disables
+	CaptchaSecret    string  // provider secret key
+	CaptchaVerifyURL string  // verification API override (e.g. a local mock)
+	CaptchaMinScore  float64 // minimum reCAPTCHA v3 score
+
+	// Proof-of-work challenges
+	PowEnabled       bool
+	PowSecret        string // signs challenges; random per process when empty
+	PowDifficulty    int    // leading zero bits required from a quiet client
+	PowMaxDifficulty int    // cap on the difficulty for busy clients
+	PowChallengeTTL  int    // in minutes
+	PowWindow        int    // in minutes; submissions from the same IP counted towards the difficulty
+
+	// Storage
+	DataDir string
+
+	// Encryption at rest of personal fields in stored records
+	EncryptionKeys      string // comma-separated id:base64key entries, including the "index" key
+	EncryptionKeysFile  string // secret file with one id:base64key entry per line
+	EncryptionActiveKey string // key ID new records are encrypted with; defaults to the last listed
+
+	// Data retention
+	RetentionDays int    // 0 keeps submissions forever
+	RetentionMode string // delete | anonymise
+
+	// Delivery Queue
+	QueueWorkers    int
+	QueueSize       int
+	ShutdownTimeout int // in seconds
+
+	// Delivery Retries
+	RetryMaxAttempts   int
+	RetryBaseDelay     int // in seconds
+	RetryMaxDelay      int // in seconds
+	RetryJitterPercent int
+
+	// Admin API
+	AdminToken string
+}
+
+// ProviderConfig holds settings for a single email provider
+type ProviderConfig struct {
+	Name     string
+	Type     string // smtp | sendgrid | mailgun | postmark | ses
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Email    string // sender "From" email address
+	APIKey   string // HTTP API providers (Postmark server token)
+	Endpoint string // HTTP API endpoint override (e.g. a local mock)
+	Domain   string // Mailgun sending domain
+
+	// Amazon SES (standard AWS environment variables)
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
 }
 
+// Provider types
+const (
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+	ProviderMailgun  = "mailgun"
+	ProviderPostmark = "postmark"
+	ProviderSES      = "ses"
+)
+
+// CAPTCHA providers
+const (
+	CaptchaHCaptcha  = "hcaptcha"
+	CaptchaReCaptcha = "recaptcha"
+	CaptchaTurnstile = "turnstile"
+)
+
+// Retention modes
+const (
+	RetentionDelete    = "delete"
+	RetentionAnonymise = "anonymise"
+)
+
 // Configuration errors
 var (
+	ErrMissingSMTPHost      = errors.New("SMTP_HOST is required")
 	ErrMissingSMTPUsername  = errors.New("SMTP_USERNAME is required")
 	ErrMissingSMTPPassword  = errors.New("SMTP_PASSWORD is required")
 	ErrMissingSMTPEmail     = errors.New("SMTP_EMAIL is required")
 	ErrMissingReceiverEmail = errors.New("RECEIVER_EMAIL is required")
+	ErrMissingSendGridKey   = errors.New("SENDGRID_API_KEY is required")
+	ErrMissingMailgunKey    = errors.New("MAILGUN_API_KEY is required")
+	ErrMissingMailgunDomain = errors.New("MAILGUN_DOMAIN is required")
+	ErrMissingPostmarkToken = errors.New("POSTMARK_SERVER_TOKEN is required")
+	ErrMissingAWSRegion     = errors.New("AWS_REGION is required")
+	ErrMissingAWSAccessKey  = errors.New("AWS_ACCESS_KEY_ID is required")
+	ErrMissingAWSSecretKey  = errors.New("AWS_SECRET_ACCESS_KEY is required")
+	ErrUnknownProviderType  = errors.New("unknown email provider type")
+	ErrInvalidRetentionMode = errors.New("RETENTION_MODE must be delete or anonymise")
+	ErrInvalidAPIKeyLimit   = errors.New("API_KEY_RATE_LIMIT must be positive")
+	ErrUnknownCaptcha       = errors.New("CAPTCHA_PROVIDER must be hcaptcha, recaptcha or turnstile")
+	ErrMissingCaptchaSecret = errors.New("CAPTCHA_SECRET is required")
+	ErrInvalidPowDifficulty = errors.New("POW_DIFFICULTY must be between 1 and POW_MAX_DIFFICULTY, which must be at most 32")
+	ErrMissingTrustedProxy  = errors.New("TRUSTED_PROXIES is required when PROXY_HEADER is set")
 )
 
 // Load loads configuration from environment variables
@@ -70,17 +196,64 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{
-		AppPort:             getEnv("APP_PORT", "3000"),
-		AppEnv:              getEnv("APP_ENV", "development"),
-		SMTPHost:            getEnv("SMTP_HOST", "smtp.gmail.com"),
-		SMTPPort:            smtpPort,
-		SMTPUsername:        getEnv("SMTP_USERNAME", ""),
-		SMTPPassword:        getEnv("SMTP_PASSWORD", ""),
-		SMTPEmail:           getEnv("SMTP_EMAIL", ""),
-		ReceiverEmail:       getEnv("RECEIVER_EMAIL", ""),
-		AllowedOrigins:      allowedOrigins,
-		RateLimit:           rateLimit,
-		RateLimitExpiration: rateLimitExpiration,
+		AppPort:                 getEnv("APP_PORT", "3000"),
+		AppEnv:                  getEnv("APP_ENV", "development"),
+		ProxyHeader:             getEnv("PROXY_HEADER", ""),
+		TrustedProxies:          getEnvList("TRUSTED_PROXIES"),
+		SMTPHost:                getEnv("SMTP_HOST", "smtp.gmail.com"),
+		SMTPPort:                smtpPort,
+		SMTPUsername:            getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:            getEnv("SMTP_PASSWORD", ""),
+		SMTPEmail:               getEnv("SMTP_EMAIL", ""),
+		ReceiverEmail:           getEnv("RECEIVER_EMAIL", ""),
+		ReceiverCC:              getEnvList("RECEIVER_CC"),
+		ReceiverBCC:             getEnvList("RECEIVER_BCC"),
+		TemplateDir:             getEnv("EMAIL_TEMPLATE_DIR", ""),
+		FormsFile:               getEnv("FORMS_CONFIG_FILE", ""),
+		RoutingFile:             getEnv("ROUTING_RULES_FILE", ""),
+		AutoReplyEnabled:        getEnvBool("AUTO_REPLY_ENABLED", false),
+		AutoReplyInterval:       getEnvInt("AUTO_REPLY_INTERVAL_MINUTES", 60),
+		CircuitBreakerThreshold: getEnvInt("CIRCUIT_BREAKER_THRESHOLD", 3),
+		CircuitBreakerCooldown:  getEnvInt("CIRCUIT_BREAKER_COOLDOWN_SECONDS", 60),
+		AllowedOrigins:          allowedOrigins,
+		RateLimit:               rateLimit,
+		RateLimitExpiration:     rateLimitExpiration,
+		APIKeyRateLimit:         getEnvInt("API_KEY_RATE_LIMIT", 60),
+		HoneypotField:           getEnv("SPAM_HONEYPOT_FIELD", ""),
+		MinSubmitSeconds:        getEnvInt("SPAM_MIN_SUBMIT_SECONDS", 0),
+		FormTokenSecret:         getEnv("SPAM_TOKEN_SECRET", ""),
+		FormTokenMaxAge:         getEnvInt("SPAM_TOKEN_MAX_AGE_HOURS", 24),
+		SpamScoringFile:         getEnv("SPAM_SCORING_FILE", ""),
+		SpamBayesFile:           getEnv("SPAM_BAYES_FILE", ""),
+		CaptchaProvider:         strings.ToLower(getEnv("CAPTCHA_PROVIDER", "")),
+		CaptchaSecret:           getEnv("CAPTCHA_SECRET", ""),
+		CaptchaVerifyURL:        getEnv("CAPTCHA_VERIFY_URL", ""),
+		CaptchaMinScore:         getEnvFloat("CAPTCHA_MIN_SCORE", 0.5),
+		PowEnabled:              getEnvBool("POW_ENABLED", false),
+		PowSecret:               getEnv("POW_SECRET", ""),
+		PowDifficulty:           getEnvInt("POW_DIFFICULTY", 16),
+		PowMaxDifficulty:        getEnvInt("POW_MAX_DIFFICULTY", 22),
+		PowChallengeTTL:         getEnvInt("POW_CHALLENGE_TTL_MINUTES", 10),
+		PowWindow:               getEnvInt("POW_WINDOW_MINUTES", 60),
+		DataDir:                 getEnv("DATA_DIR", "./data"),
+		EncryptionKeys:          getEnv("ENCRYPTION_KEYS", ""),
+		EncryptionKeysFile:      getEnv("ENCRYPTION_KEYS_FILE", ""),
+		EncryptionActiveKey:     getEnv("ENCRYPTION_ACTIVE_KEY", ""),
+		RetentionDays:           getEnvInt("RETENTION_DAYS", 0),
+		RetentionMode:           strings.ToLower(getEnv("RETENTION_MODE", RetentionDelete)),
+		QueueWorkers:            getEnvInt("QUEUE_WORKERS", 2),
+		QueueSize:               getEnvInt("QUEUE_SIZE", 100),
+		ShutdownTimeout:         getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 30),
+		RetryMaxAttempts:        getEnvInt("RETRY_MAX_ATTEMPTS", 5),
+		RetryBaseDelay:          getEnvInt("RETRY_BASE_DELAY_SECONDS", 2),
+		RetryMaxDelay:           getEnvInt("RETRY_MAX_DELAY_SECONDS", 60),
+		RetryJitterPercent:      getEnvInt("RETRY_JITTER_PERCENT", 20),
+		AdminToken:              getEnv("ADMIN_TOKEN", ""),
+	}
+
+	cfg.Providers = loadProviders(cfg)
+	if cfg.SpamBayesFile == "" {
+		cfg.SpamBayesFile = filepath.Join(cfg.DataDir, "bayes.json")
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -90,23 +263,193 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
-// Validate checks if all required configuration is present
-func (c *Config) Validate() error {
-	if c.SMTPUsername == "" {
-		return ErrMissingSMTPUsername
+// loadProviders reads the ordered provider list from EMAIL_PROVIDERS.
+// Each named provider is configured with PROVIDER_<NAME>_* variables;
+// without EMAIL_PROVIDERS a single provider of type EMAIL_PROVIDER is
+// built from SMTP_* or the provider's own variables.
+func loadProviders(cfg *Config) []ProviderConfig {
+	names := getEnv("EMAIL_PROVIDERS", "")
+	if names == "" {
+		provider := ProviderConfig{
+			Name:     "default",
+			Type:     strings.ToLower(getEnv("EMAIL_PROVIDER", ProviderSMTP)),
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			Email:    cfg.SMTPEmail,
+		}
+
+		switch provider.Type {
+		case ProviderSendGrid:
+			provider.APIKey = getEnv("SENDGRID_API_KEY", "")
+			provider.Endpoint = getEnv("SENDGRID_ENDPOINT", "")
+		case ProviderMailgun:
+			provider.APIKey = getEnv("MAILGUN_API_KEY", "")
+			provider.Domain = getEnv("MAILGUN_DOMAIN", "")
+			provider.Endpoint = getEnv("MAILGUN_ENDPOINT", "")
+		case ProviderPostmark:
+			provider.APIKey = getEnv("POSTMARK_SERVER_TOKEN", "")
+			provider.Endpoint = getEnv("POSTMARK_ENDPOINT", "")
+		case ProviderSES:
+			provider.Endpoint = getEnv("SES_ENDPOINT", "")
+		}
+
+		if provider.Type == ProviderSES {
+			loadAWSCredentials(&provider)
+		}
+
+		return []ProviderConfig{provider}
 	}
-	if c.SMTPPassword == "" {
-		return ErrMissingSMTPPassword
+
+	var providers []ProviderConfig
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		prefix := "PROVIDER_" + strings.ToUpper(name) + "_"
+		providers = append(providers, ProviderConfig{
+			Name:     name,
+			Type:     strings.ToLower(getEnv(prefix+"TYPE", ProviderSMTP)),
+			Host:     getEnv(prefix+"HOST", ""),
+			Port:     getEnvInt(prefix+"PORT", 587),
+			Username: getEnv(prefix+"USERNAME", ""),
+			Password: getEnv(prefix+"PASSWORD", ""),
+			Email:    getEnv(prefix+"EMAIL", cfg.SMTPEmail),
+			APIKey:   getEnv(prefix+"API_KEY", ""),
+			Endpoint: getEnv(prefix+"ENDPOINT", ""),
+			Domain:   getEnv(prefix+"DOMAIN", ""),
+		})
+	}
+
+	for i := range providers {
+		if providers[i].Type == ProviderSES {
+			loadAWSCredentials(&providers[i])
+		}
 	}
+	return providers
+}
+
+// loadAWSCredentials fills an SES provider from the standard AWS environment
+// variables; a named provider may override the region with PROVIDER_<NAME>_REGION
+func loadAWSCredentials(p *ProviderConfig) {
+	region := getEnvWithFallback("AWS_REGION", "AWS_DEFAULT_REGION", "")
+	if p.Name != "default" {
+		region = getEnv("PROVIDER_"+strings.ToUpper(p.Name)+"_REGION", region)
+	}
+
+	p.Region = region
+	p.AccessKeyID = getEnv("AWS_ACCESS_KEY_ID", "")
+	p.SecretAccessKey = getEnv("AWS_SECRET_ACCESS_KEY", "")
+	p.SessionToken = getEnv("AWS_SESSION_TOKEN", "")
+}
+
+// Validate checks if all required configuration is present
+func (c *Config) Validate() error {
 	if c.SMTPEmail == "" {
 		return ErrMissingSMTPEmail
 	}
 	if c.ReceiverEmail == "" {
 		return ErrMissingReceiverEmail
 	}
+	if err := c.Recipients().Validate(); err != nil {
+		return fmt.Errorf("invalid RECEIVER_EMAIL, RECEIVER_CC or RECEIVER_BCC: %w", err)
+	}
+	if c.RetentionMode != RetentionDelete && c.RetentionMode != RetentionAnonymise {
+		return ErrInvalidRetentionMode
+	}
+	if c.APIKeyRateLimit <= 0 {
+		return ErrInvalidAPIKeyLimit
+	}
+	if c.ProxyHeader != "" && len(c.TrustedProxies) == 0 {
+		return ErrMissingTrustedProxy
+	}
+	if c.PowEnabled && (c.PowDifficulty < 1 || c.PowMaxDifficulty < c.PowDifficulty || c.PowMaxDifficulty > 32) {
+		return ErrInvalidPowDifficulty
+	}
+	switch c.CaptchaProvider {
+	case "":
+	case CaptchaHCaptcha, CaptchaReCaptcha, CaptchaTurnstile:
+		if c.CaptchaSecret == "" {
+			return ErrMissingCaptchaSecret
+		}
+	default:
+		return ErrUnknownCaptcha
+	}
+	for _, p := range c.Providers {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Validate checks if all required provider configuration is present
+func (p ProviderConfig) Validate() error {
+	switch p.Type {
+	case ProviderSMTP:
+		if p.Host == "" {
+			return p.missing("HOST", ErrMissingSMTPHost)
+		}
+		if p.Username == "" {
+			return p.missing("USERNAME", ErrMissingSMTPUsername)
+		}
+		if p.Password == "" {
+			return p.missing("PASSWORD", ErrMissingSMTPPassword)
+		}
+	case ProviderSendGrid:
+		if p.APIKey == "" {
+			return p.missing("API_KEY", ErrMissingSendGridKey)
+		}
+	case ProviderMailgun:
+		if p.APIKey == "" {
+			return p.missing("API_KEY", ErrMissingMailgunKey)
+		}
+		if p.Domain == "" {
+			return p.missing("DOMAIN", ErrMissingMailgunDomain)
+		}
+	case ProviderPostmark:
+		if p.APIKey == "" {
+			return p.missing("API_KEY", ErrMissingPostmarkToken)
+		}
+	case ProviderSES:
+		if p.Region == "" {
+			return ErrMissingAWSRegion
+		}
+		if p.AccessKeyID == "" {
+			return ErrMissingAWSAccessKey
+		}
+		if p.SecretAccessKey == "" {
+			return ErrMissingAWSSecretKey
+		}
+	default:
+		return fmt.Errorf("%w %q for provider %s", ErrUnknownProviderType, p.Type, p.Name)
+	}
+	return nil
+}
+
+// missing returns err for the default provider, or names the
+// PROVIDER_<NAME>_<key> variable for a named provider
+func (p ProviderConfig) missing(key string, err error) error {
+	if p.Name == "default" {
+		return err
+	}
+	return fmt.Errorf("PROVIDER_%s_%s is required", strings.ToUpper(p.Name), key)
+}
+
+// Recipients returns the default notification recipients
+func (c *Config) Recipients() entity.Recipients {
+	return entity.NewRecipients(splitList(c.ReceiverEmail), c.ReceiverCC, c.ReceiverBCC)
+}
+
+// HasBotChecks reports whether submissions must pass a CAPTCHA, a
+// proof-of-work challenge or a form token before they are accepted
+func (c *Config) HasBotChecks() bool {
+	return c.CaptchaProvider != "" || c.PowEnabled || c.MinSubmitSeconds > 0
+}
+
 // IsProduction returns true if running in production environment
 func (c *Config) IsProduction() bool {
 	return c.AppEnv == "production"
@@ -120,6 +463,48 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(defaultValue)))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat gets a float environment variable or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvBool gets a boolean environment variable or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvList gets a comma-separated environment variable as a list
+func getEnvList(key string) []string {
+	return splitList(os.Getenv(key))
+}
+
+// splitList splits a comma-separated list, dropping blank entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvWithFallback tries the primary key first, then falls back to an alternate key
 func getEnvWithFallback(primary, fallback, defaultValue string) string {
 	if value := os.Getenv(primary); value != "" {
@@ -0,0 +1,45 @@
+package email
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// NewEmailRepository creates the email repository described by the configuration.
+// A single provider is used directly; several are wrapped in a failover repository.
+func NewEmailRepository(cfg *config.Config, templates *Templates) repository.EmailRepository {
+	providers := make([]Provider, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		providers = append(providers, Provider{
+			Name:       p.Name,
+			Repository: newProviderRepository(cfg, p, templates),
+		})
+	}
+
+	if len(providers) == 1 {
+		return providers[0].Repository
+	}
+
+	return NewFailoverRepository(providers, BreakerConfig{
+		Threshold: cfg.CircuitBreakerThreshold,
+		Cooldown:  time.Duration(cfg.CircuitBreakerCooldown) * time.Second,
+	})
+}
+
+// newProviderRepository creates the repository for a single provider
+func newProviderRepository(cfg *config.Config, provider config.ProviderConfig, templates *Templates) repository.EmailRepository {
+	switch provider.Type {
+	case config.ProviderSendGrid:
+		return NewSendGridRepository(cfg, provider, templates)
+	case config.ProviderMailgun:
+		return NewMailgunRepository(cfg, provider, templates)
+	case config.ProviderPostmark:
+		return NewPostmarkRepository(cfg, provider, templates)
+	case config.ProviderSES:
+		return NewSESRepository(cfg, provider, templates)
+	default:
+		return NewSMTPRepository(cfg, provider, templates)
+	}
+}
@@ -0,0 +1,115 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// ErrNoProviderAvailable is returned when every provider's circuit breaker is open
+var ErrNoProviderAvailable = errors.New("no email provider available")
+
+// Provider is a named email repository used by the failover repository
+type Provider struct {
+	Name       string
+	Repository repository.EmailRepository
+}
+
+// BreakerConfig holds circuit breaker configuration
+type BreakerConfig struct {
+	Threshold int
+	Cooldown  time.Duration
+}
+
+// failoverProvider pairs a provider with its circuit breaker
+type failoverProvider struct {
+	Provider
+	breaker *circuitBreaker
+}
+
+// failoverRepository implements EmailRepository by trying providers in order
+type failoverRepository struct {
+	providers []*failoverProvider
+}
+
+// NewFailoverRepository creates an email repository that tries each provider
+// in order, skipping providers whose circuit breaker is open
+func NewFailoverRepository(providers []Provider, cfg BreakerConfig) repository.EmailRepository {
+	r := &failoverRepository{}
+	for _, p := range providers {
+		r.providers = append(r.providers, &failoverProvider{
+			Provider: p,
+			breaker:  newCircuitBreaker(cfg.Threshold, cfg.Cooldown),
+		})
+	}
+	return r
+}
+
+// Send sends an email via the first healthy provider that succeeds
+func (r *failoverRepository) Send(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	return r.try(func(repo repository.EmailRepository) (*entity.DeliveryReceipt, error) {
+		return repo.Send(contact)
+	}, true)
+}
+
+// SendConfirmation sends the confirmation via the first healthy provider that
+// succeeds. Auto-replies go to unverified addresses, so their results are not
+// reported to the circuit breakers.
+func (r *failoverRepository) SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	return r.try(func(repo repository.EmailRepository) (*entity.DeliveryReceipt, error) {
+		return repo.SendConfirmation(contact)
+	}, false)
+}
+
+// try calls send with each healthy provider in order until one succeeds.
+// The error is only permanent if every attempted provider failed permanently.
+// A permanent error is about the message rather than the provider, so it
+// doesn't count against the provider's breaker. When report is false the
+// breakers are only consulted, never updated.
+func (r *failoverRepository) try(send func(repo repository.EmailRepository) (*entity.DeliveryReceipt, error), report bool) (*entity.DeliveryReceipt, error) {
+	var errs []error
+	permanent := true
+
+	for _, p := range r.providers {
+		if report && !p.breaker.Allow() || !report && !p.breaker.Closed() {
+			continue
+		}
+
+		receipt, err := send(p.Repository)
+		if err == nil {
+			if report {
+				p.breaker.Success()
+			}
+			return receipt, nil
+		}
+
+		if repository.IsPermanent(err) {
+			if report {
+				p.breaker.Release()
+			}
+		} else {
+			permanent = false
+			if report && p.breaker.Failure() {
+				log.Printf("[FailoverRepository] Circuit opened for provider %s", p.Name)
+			}
+		}
+		log.Printf("[FailoverRepository] Provider %s failed, trying next: %v", p.Name, entity.RedactAddresses(err.Error()))
+
+		errs = append(errs, fmt.Errorf("%s: %v", p.Name, err))
+	}
+
+	if len(errs) == 0 {
+		return nil, ErrNoProviderAvailable
+	}
+
+	// Provider errors are flattened so their permanent markers don't leak
+	err := errors.Join(errs...)
+	if permanent {
+		return nil, fmt.Errorf("%w: %v", repository.ErrPermanentFailure, err)
+	}
+	return nil, fmt.Errorf("all email providers failed: %v", err)
+}
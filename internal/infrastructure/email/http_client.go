@@ -0,0 +1,81 @@
+package email
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// httpTimeout bounds a single API call to an HTTP email provider
+const httpTimeout = 15 * time.Second
+
+// maxErrorBody limits how much of an error response is kept for logging
+const maxErrorBody = 1024
+
+// errorClassifier converts a non-2xx API response into a delivery error
+type errorClassifier func(resp *http.Response, body []byte) error
+
+// newHTTPClient creates the HTTP client shared by API-based providers
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: httpTimeout}
+}
+
+// doRequest sends req and returns the response and its body for 2xx responses.
+// Network errors are transient; other statuses are mapped by classify.
+func doRequest(client *http.Client, req *http.Request, classify errorClassifier) (*http.Response, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, body, nil
+	}
+
+	return resp, body, classify(resp, body)
+}
+
+// statusError is the default errorClassifier: 429, 5xx and credential
+// failures (401/403) are transient, other statuses are permanent
+func statusError(resp *http.Response, body []byte) error {
+	return classifyStatus(resp.StatusCode, truncate(string(body)))
+}
+
+// classifyStatus builds a delivery error for status with a readable detail
+func classifyStatus(status int, detail string) error {
+	err := fmt.Errorf("unexpected status %d: %s", status, detail)
+	if isRetryableStatus(status) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", repository.ErrPermanentFailure, err)
+}
+
+// isRetryableStatus reports whether an HTTP status is worth retrying. A
+// rejected API key (401/403) is a fault of the provider account, not the
+// message, so it counts against the breaker and another provider is tried.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	}
+	return status >= 500
+}
+
+// truncate trims an error body to a loggable size
+func truncate(detail string) string {
+	detail = strings.TrimSpace(detail)
+	if len(detail) > maxErrorBody {
+		detail = detail[:maxErrorBody]
+	}
+	return detail
+}
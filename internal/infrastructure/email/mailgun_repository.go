@@ -0,0 +1,128 @@
+package email
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// mailgunEndpoint is the Mailgun API base URL (use https://api.eu.mailgun.net for EU domains)
+const mailgunEndpoint = "https://api.mailgun.net"
+
+// mailgunRepository implements EmailRepository using the Mailgun HTTP API
+type mailgunRepository struct {
+	name        string
+	endpoint    string
+	apiKey      string
+	domain      string
+	client      *http.Client
+	templates   *Templates
+	senderEmail string
+	recipients  entity.Recipients
+}
+
+// mailgunResponse is the body of a Mailgun response; ID is only set on success
+type mailgunResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// NewMailgunRepository creates a new Mailgun email repository for the given provider
+func NewMailgunRepository(cfg *config.Config, provider config.ProviderConfig, templates *Templates) repository.EmailRepository {
+	endpoint := provider.Endpoint
+	if endpoint == "" {
+		endpoint = mailgunEndpoint
+	}
+
+	return &mailgunRepository{
+		name:        provider.Name,
+		endpoint:    strings.TrimRight(endpoint, "/"),
+		apiKey:      provider.APIKey,
+		domain:      provider.Domain,
+		client:      newHTTPClient(),
+		templates:   templates,
+		senderEmail: provider.Email,
+		recipients:  cfg.Recipients(),
+	}
+}
+
+// Send sends an email based on contact information
+func (r *mailgunRepository) Send(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newNotification(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[MailgunRepository] Provider %s failed to render email: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// SendConfirmation sends the submitter a confirmation with a copy of their message
+func (r *mailgunRepository) SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newConfirmation(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[MailgunRepository] Provider %s failed to render confirmation: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// send delivers a rendered message and returns the provider's receipt
+func (r *mailgunRepository) send(msg *message) (*entity.DeliveryReceipt, error) {
+	form := url.Values{}
+	form.Set("from", msg.From)
+	form.Set("to", strings.Join(msg.To, ","))
+	if len(msg.CC) > 0 {
+		form.Set("cc", strings.Join(msg.CC, ","))
+	}
+	if len(msg.BCC) > 0 {
+		form.Set("bcc", strings.Join(msg.BCC, ","))
+	}
+	form.Set("subject", msg.Subject)
+	form.Set("text", msg.Text)
+	form.Set("html", msg.HTML)
+	if msg.ReplyTo != "" {
+		form.Set("h:Reply-To", msg.ReplyTo)
+	}
+	for name, value := range msg.Headers {
+		form.Set("h:"+name, value)
+	}
+
+	endpoint := fmt.Sprintf("%s/v3/%s/messages", r.endpoint, url.PathEscape(r.domain))
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %w", repository.ErrPermanentFailure, err)
+	}
+	req.SetBasicAuth("api", r.apiKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	_, respBody, err := doRequest(r.client, req, mailgunError)
+	if err != nil {
+		log.Printf("[MailgunRepository] Provider %s failed to send email: %v", r.name, entity.RedactAddresses(err.Error()))
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+
+	// The message was accepted; a missing ID in the response is not an error
+	var result mailgunResponse
+	_ = json.Unmarshal(respBody, &result)
+	messageID := result.ID
+
+	log.Printf("[MailgunRepository] Provider %s sent email successfully (message ID %s)", r.name, messageID)
+	return &entity.DeliveryReceipt{Provider: r.name, MessageID: messageID}, nil
+}
+
+// mailgunError maps a Mailgun error response; Mailgun reports the reason in
+// a JSON "message" field and signals throttling and outages via 429 and 5xx
+func mailgunError(resp *http.Response, body []byte) error {
+	var errResp mailgunResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Message == "" {
+		return statusError(resp, body)
+	}
+	return classifyStatus(resp.StatusCode, truncate(errResp.Message))
+}
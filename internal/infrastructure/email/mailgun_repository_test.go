@@ -0,0 +1,100 @@
+package email
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+func TestMailgunRepositorySend(t *testing.T) {
+	server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/mg.example.com/messages" {
+			t.Errorf("request = %s %s, want POST /v3/mg.example.com/messages", r.Method, r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "api" || pass != "key-test" {
+			t.Errorf("BasicAuth() = %q, %q, %v, want api, key-test", user, pass, ok)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q, want %q", ct, "application/x-www-form-urlencoded")
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+
+		want := map[string]string{
+			"from":       "noreply@example.com",
+			"to":         "owner@example.com",
+			"subject":    "[Portfolio Contact] Order #42",
+			"h:Reply-To": "john.doe@example.com",
+		}
+		for name, value := range want {
+			if got := r.PostForm.Get(name); got != value {
+				t.Errorf("form %s = %q, want %q", name, got, value)
+			}
+		}
+		if r.PostForm.Get("text") == "" || r.PostForm.Get("html") == "" {
+			t.Errorf("form text and html must both be set")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"<mg-message-1@mg.example.com>","message":"Queued. Thank you."}`))
+	})
+
+	repo := NewMailgunRepository(testConfig(), config.ProviderConfig{
+		Name:     "mailgun",
+		Email:    "noreply@example.com",
+		APIKey:   "key-test",
+		Domain:   "mg.example.com",
+		Endpoint: server.URL + "/",
+	}, testTemplates(t))
+
+	receipt, err := repo.Send(testContact())
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if receipt.Provider != "mailgun" || receipt.MessageID != "<mg-message-1@mg.example.com>" {
+		t.Errorf("Send() = %+v, want provider %q and message ID %q", receipt, "mailgun", "<mg-message-1@mg.example.com>")
+	}
+}
+
+func TestMailgunRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantPermanent bool
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{"message":"Too many requests"}`, wantPermanent: false},
+		{name: "server error", status: http.StatusBadGateway, body: `upstream error`, wantPermanent: false},
+		{name: "invalid API key", status: http.StatusUnauthorized, body: `Forbidden`, wantPermanent: false},
+		{name: "bad request", status: http.StatusBadRequest, body: `{"message":"'to' parameter is not a valid address"}`, wantPermanent: true},
+		{name: "unknown domain", status: http.StatusNotFound, body: `{"message":"Domain not found"}`, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			repo := NewMailgunRepository(testConfig(), config.ProviderConfig{
+				Name:     "mailgun",
+				Email:    "noreply@example.com",
+				APIKey:   "key-test",
+				Domain:   "mg.example.com",
+				Endpoint: server.URL,
+			}, testTemplates(t))
+
+			_, err := repo.Send(testContact())
+			if err == nil {
+				t.Fatalf("Send() error = nil, want status %d", tt.status)
+			}
+			if got := repository.IsPermanent(err); got != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, got, tt.wantPermanent)
+			}
+		})
+	}
+}
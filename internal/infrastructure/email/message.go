@@ -0,0 +1,120 @@
+package email
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// message is a provider-independent outgoing email
+type message struct {
+	From    string
+	To      []string
+	CC      []string
+	BCC     []string
+	ReplyTo string
+	Subject string
+	HTML    string
+	Text    string // plain-text alternative of HTML
+	Headers map[string]string
+}
+
+// notificationData is the data available to the notification and confirmation templates
+type notificationData struct {
+	ID          string
+	FormID      string
+	Name        string
+	Email       string
+	Subject     string
+	Message     string
+	Fields      []entity.FieldValue // every submitted field of a configured form
+	SubmittedAt time.Time
+}
+
+// newNotificationData returns the template data for a contact.
+// Escaping is left to html/template, so contact fields are passed as-is.
+func newNotificationData(contact *entity.Contact) *notificationData {
+	return &notificationData{
+		ID:          contact.ID,
+		FormID:      contact.FormID,
+		Name:        contact.Name,
+		Email:       contact.Email,
+		Subject:     contact.Subject,
+		Message:     contact.Message,
+		Fields:      contact.Fields,
+		SubmittedAt: contact.SubmittedAt,
+	}
+}
+
+// newNotification builds the site owner notification for a contact, sent to
+// the contact's own recipients when its form declares them
+func newNotification(templates *Templates, from string, recipients entity.Recipients, contact *entity.Contact) (*message, error) {
+	out, err := templates.Render(templateNotification, newNotificationData(contact))
+	if err != nil {
+		return nil, err
+	}
+
+	if !contact.Recipients.IsZero() {
+		recipients = contact.Recipients
+	}
+
+	msg := &message{
+		From:    from,
+		To:      recipients.To,
+		CC:      recipients.CC,
+		BCC:     recipients.BCC,
+		ReplyTo: contact.Email,
+		Subject: out.Subject,
+		HTML:    out.HTML,
+		Text:    out.Text,
+	}
+
+	// Show the site owner how the contact scored
+	if contact.Spam != nil {
+		msg.Headers = map[string]string{"X-Spam-Score": contact.Spam.String()}
+		if contact.Spam.Action == entity.SpamActionTag {
+			msg.Subject = entity.SpamSubjectTag + " " + msg.Subject
+		}
+	}
+
+	return msg, nil
+}
+
+// newConfirmation builds the auto-reply sent to the submitter of a contact.
+// Replies go to the site owner (the first To recipient) rather than back to the sender address.
+func newConfirmation(templates *Templates, from string, recipients entity.Recipients, contact *entity.Contact) (*message, error) {
+	out, err := templates.Render(templateConfirmation, newNotificationData(contact))
+	if err != nil {
+		return nil, err
+	}
+
+	if !contact.Recipients.IsZero() {
+		recipients = contact.Recipients
+	}
+
+	var owner string
+	if len(recipients.To) > 0 {
+		owner = recipients.To[0]
+	}
+
+	return &message{
+		From:    from,
+		To:      []string{contact.Email},
+		ReplyTo: owner,
+		Subject: out.Subject,
+		HTML:    out.HTML,
+		Text:    out.Text,
+	}, nil
+}
+
+// newMessageID returns a unique Message-ID in the domain of the sender address
+func newMessageID(from string) string {
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 && i < len(from)-1 {
+		domain = from[i+1:]
+	}
+	return "<" + uuid.NewString() + "@" + domain + ">"
+}
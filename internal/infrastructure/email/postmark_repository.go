@@ -0,0 +1,168 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// postmarkEndpoint is the Postmark single email API
+const postmarkEndpoint = "https://api.postmarkapp.com/email"
+
+// Postmark error codes that are faults of the provider rather than the message
+const (
+	postmarkInvalidToken = 10  // bad or missing server token
+	postmarkMaintenance  = 100 // API offline for maintenance
+)
+
+// postmarkRepository implements EmailRepository using the Postmark HTTP API
+type postmarkRepository struct {
+	name        string
+	endpoint    string
+	serverToken string
+	client      *http.Client
+	templates   *Templates
+	senderEmail string
+	recipients  entity.Recipients
+}
+
+// postmarkRequest is the /email request body
+type postmarkRequest struct {
+	From     string           `json:"From"`
+	To       string           `json:"To"`
+	Cc       string           `json:"Cc,omitempty"`
+	Bcc      string           `json:"Bcc,omitempty"`
+	ReplyTo  string           `json:"ReplyTo,omitempty"`
+	Subject  string           `json:"Subject"`
+	HTMLBody string           `json:"HtmlBody"`
+	TextBody string           `json:"TextBody"`
+	Headers  []postmarkHeader `json:"Headers,omitempty"`
+}
+
+// postmarkHeader is a custom header of a Postmark request
+type postmarkHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// postmarkResponse is the body of a successful /email response
+type postmarkResponse struct {
+	MessageID string `json:"MessageID"`
+}
+
+// postmarkErrorResponse is the body of a Postmark error response
+type postmarkErrorResponse struct {
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message"`
+}
+
+// NewPostmarkRepository creates a new Postmark email repository for the given provider
+func NewPostmarkRepository(cfg *config.Config, provider config.ProviderConfig, templates *Templates) repository.EmailRepository {
+	endpoint := provider.Endpoint
+	if endpoint == "" {
+		endpoint = postmarkEndpoint
+	}
+
+	return &postmarkRepository{
+		name:        provider.Name,
+		endpoint:    endpoint,
+		serverToken: provider.APIKey,
+		client:      newHTTPClient(),
+		templates:   templates,
+		senderEmail: provider.Email,
+		recipients:  cfg.Recipients(),
+	}
+}
+
+// Send sends an email based on contact information
+func (r *postmarkRepository) Send(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newNotification(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[PostmarkRepository] Provider %s failed to render email: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// SendConfirmation sends the submitter a confirmation with a copy of their message
+func (r *postmarkRepository) SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newConfirmation(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[PostmarkRepository] Provider %s failed to render confirmation: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// send delivers a rendered message and returns the provider's receipt
+func (r *postmarkRepository) send(msg *message) (*entity.DeliveryReceipt, error) {
+	payload, err := json.Marshal(&postmarkRequest{
+		From:     msg.From,
+		To:       strings.Join(msg.To, ","),
+		Cc:       strings.Join(msg.CC, ","),
+		Bcc:      strings.Join(msg.BCC, ","),
+		ReplyTo:  msg.ReplyTo,
+		Subject:  msg.Subject,
+		HTMLBody: msg.HTML,
+		TextBody: msg.Text,
+		Headers:  postmarkHeaders(msg.Headers),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %w", repository.ErrPermanentFailure, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %w", repository.ErrPermanentFailure, err)
+	}
+	req.Header.Set("X-Postmark-Server-Token", r.serverToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	_, respBody, err := doRequest(r.client, req, postmarkError)
+	if err != nil {
+		log.Printf("[PostmarkRepository] Provider %s failed to send email: %v", r.name, entity.RedactAddresses(err.Error()))
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+
+	// The message was accepted; a missing ID in the response is not an error
+	var result postmarkResponse
+	_ = json.Unmarshal(respBody, &result)
+	messageID := result.MessageID
+
+	log.Printf("[PostmarkRepository] Provider %s sent email successfully (message ID %s)", r.name, messageID)
+	return &entity.DeliveryReceipt{Provider: r.name, MessageID: messageID}, nil
+}
+
+// postmarkError maps a Postmark error response. Postmark reports most
+// failures as 422 with an ErrorCode (invalid token, inactive recipient, ...)
+// which are permanent, except an invalid token and maintenance, which are
+// faults of the provider and worth retrying or failing over.
+func postmarkError(resp *http.Response, body []byte) error {
+	var errResp postmarkErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.ErrorCode == 0 {
+		return statusError(resp, body)
+	}
+
+	detail := fmt.Sprintf("postmark error %d: %s", errResp.ErrorCode, truncate(errResp.Message))
+	if errResp.ErrorCode == postmarkInvalidToken || errResp.ErrorCode == postmarkMaintenance {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	return classifyStatus(resp.StatusCode, detail)
+}
+
+// postmarkHeaders converts message headers to the Postmark header list
+func postmarkHeaders(headers map[string]string) []postmarkHeader {
+	var out []postmarkHeader
+	for name, value := range headers {
+		out = append(out, postmarkHeader{Name: name, Value: value})
+	}
+	return out
+}
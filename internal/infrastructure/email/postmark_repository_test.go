@@ -0,0 +1,96 @@
+package email
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+func TestPostmarkRepositorySend(t *testing.T) {
+	var got postmarkRequest
+	server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/email" {
+			t.Errorf("request = %s %s, want POST /email", r.Method, r.URL.Path)
+		}
+		if token := r.Header.Get("X-Postmark-Server-Token"); token != "server-token" {
+			t.Errorf("X-Postmark-Server-Token = %q, want %q", token, "server-token")
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"To":"owner@example.com","MessageID":"pm-message-1","ErrorCode":0,"Message":"OK"}`))
+	})
+
+	repo := NewPostmarkRepository(testConfig(), config.ProviderConfig{
+		Name:     "postmark",
+		Email:    "noreply@example.com",
+		APIKey:   "server-token",
+		Endpoint: server.URL + "/email",
+	}, testTemplates(t))
+
+	receipt, err := repo.Send(testContact())
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if receipt.Provider != "postmark" || receipt.MessageID != "pm-message-1" {
+		t.Errorf("Send() = %+v, want provider %q and message ID %q", receipt, "postmark", "pm-message-1")
+	}
+
+	if got.From != "noreply@example.com" || got.To != "owner@example.com" || got.ReplyTo != "john.doe@example.com" {
+		t.Errorf("From, To, ReplyTo = %q, %q, %q, want noreply@, owner@, john.doe@example.com", got.From, got.To, got.ReplyTo)
+	}
+	if got.Subject != "[Portfolio Contact] Order #42" {
+		t.Errorf("Subject = %q, want %q", got.Subject, "[Portfolio Contact] Order #42")
+	}
+	if got.TextBody == "" || got.HTMLBody == "" {
+		t.Errorf("TextBody and HtmlBody must both be set")
+	}
+}
+
+func TestPostmarkRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantPermanent bool
+	}{
+		{name: "invalid token", status: http.StatusUnprocessableEntity, body: `{"ErrorCode":10,"Message":"Bad or missing API token"}`, wantPermanent: false},
+		{name: "maintenance", status: http.StatusUnprocessableEntity, body: `{"ErrorCode":100,"Message":"Maintenance"}`, wantPermanent: false},
+		{name: "inactive recipient", status: http.StatusUnprocessableEntity, body: `{"ErrorCode":406,"Message":"Inactive recipient"}`, wantPermanent: true},
+		{name: "invalid email request", status: http.StatusUnprocessableEntity, body: `{"ErrorCode":300,"Message":"Invalid email request"}`, wantPermanent: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `Too many requests`, wantPermanent: false},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"ErrorCode":0,"Message":"Internal error"}`, wantPermanent: false},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: ``, wantPermanent: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			repo := NewPostmarkRepository(testConfig(), config.ProviderConfig{
+				Name:     "postmark",
+				Email:    "noreply@example.com",
+				APIKey:   "server-token",
+				Endpoint: server.URL,
+			}, testTemplates(t))
+
+			_, err := repo.Send(testContact())
+			if err == nil {
+				t.Fatalf("Send() error = nil, want status %d", tt.status)
+			}
+			if got := repository.IsPermanent(err); got != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, got, tt.wantPermanent)
+			}
+		})
+	}
+}
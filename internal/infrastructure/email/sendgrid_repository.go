@@ -0,0 +1,150 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// sendGridEndpoint is the SendGrid v3 mail send API
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// sendGridRepository implements EmailRepository using the SendGrid v3 HTTP API
+type sendGridRepository struct {
+	name        string
+	endpoint    string
+	apiKey      string
+	client      *http.Client
+	templates   *Templates
+	senderEmail string
+	recipients  entity.Recipients
+}
+
+// sendGridAddress is an email address in a SendGrid request
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+// sendGridContent is a body part in a SendGrid request
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// sendGridPersonalization holds the recipients of a SendGrid request
+type sendGridPersonalization struct {
+	To  []sendGridAddress `json:"to"`
+	CC  []sendGridAddress `json:"cc,omitempty"`
+	BCC []sendGridAddress `json:"bcc,omitempty"`
+}
+
+// sendGridRequest is the /v3/mail/send request body
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+}
+
+// NewSendGridRepository creates a new SendGrid email repository for the given provider
+func NewSendGridRepository(cfg *config.Config, provider config.ProviderConfig, templates *Templates) repository.EmailRepository {
+	endpoint := provider.Endpoint
+	if endpoint == "" {
+		endpoint = sendGridEndpoint
+	}
+
+	return &sendGridRepository{
+		name:        provider.Name,
+		endpoint:    endpoint,
+		apiKey:      provider.APIKey,
+		client:      newHTTPClient(),
+		templates:   templates,
+		senderEmail: provider.Email,
+		recipients:  cfg.Recipients(),
+	}
+}
+
+// Send sends an email based on contact information
+func (r *sendGridRepository) Send(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newNotification(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[SendGridRepository] Provider %s failed to render email: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// SendConfirmation sends the submitter a confirmation with a copy of their message
+func (r *sendGridRepository) SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newConfirmation(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[SendGridRepository] Provider %s failed to render confirmation: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// send delivers a rendered message and returns the provider's receipt
+func (r *sendGridRepository) send(msg *message) (*entity.DeliveryReceipt, error) {
+	body := &sendGridRequest{
+		Personalizations: []sendGridPersonalization{
+			{
+				To:  sendGridAddresses(msg.To),
+				CC:  sendGridAddresses(msg.CC),
+				BCC: sendGridAddresses(msg.BCC),
+			},
+		},
+		From:    sendGridAddress{Email: msg.From},
+		Subject: msg.Subject,
+		Headers: msg.Headers,
+		// SendGrid requires text/plain before text/html
+		Content: []sendGridContent{
+			{Type: "text/plain", Value: msg.Text},
+			{Type: "text/html", Value: msg.HTML},
+		},
+	}
+	if msg.ReplyTo != "" {
+		body.ReplyTo = &sendGridAddress{Email: msg.ReplyTo}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %w", repository.ErrPermanentFailure, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %w", repository.ErrPermanentFailure, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _, err := doRequest(r.client, req, statusError)
+	if err != nil {
+		log.Printf("[SendGridRepository] Provider %s failed to send email: %v", r.name, entity.RedactAddresses(err.Error()))
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+
+	// SendGrid returns no body; the message ID is in a header
+	messageID := resp.Header.Get("X-Message-Id")
+
+	log.Printf("[SendGridRepository] Provider %s sent email successfully (message ID %s)", r.name, messageID)
+	return &entity.DeliveryReceipt{Provider: r.name, MessageID: messageID}, nil
+}
+
+// sendGridAddresses converts email addresses into SendGrid addresses
+func sendGridAddresses(emails []string) []sendGridAddress {
+	var addresses []sendGridAddress
+	for _, e := range emails {
+		addresses = append(addresses, sendGridAddress{Email: e})
+	}
+	return addresses
+}
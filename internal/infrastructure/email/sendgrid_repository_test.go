@@ -0,0 +1,135 @@
+package email
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// testConfig returns a config that delivers to a single receiver
+func testConfig() *config.Config {
+	return &config.Config{ReceiverEmail: "owner@example.com"}
+}
+
+// testTemplates loads the embedded templates, failing the test on error
+func testTemplates(t *testing.T) *Templates {
+	t.Helper()
+	templates, err := NewTemplates("")
+	if err != nil {
+		t.Fatalf("failed to load templates: %v", err)
+	}
+	return templates
+}
+
+// testContact returns a contact submitted through the default form
+func testContact() *entity.Contact {
+	return &entity.Contact{
+		ID:      "018f3c2e-7b1a-7c3d-9f4e-2a1b3c4d5e6f",
+		Name:    "John Doe",
+		Email:   "john.doe@example.com",
+		Subject: "Order #42",
+		Message: "Where is my order?",
+	}
+}
+
+// testServer serves handler and closes it when the test ends
+func testServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSendGridRepositorySend(t *testing.T) {
+	var got sendGridRequest
+	server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/mail/send" {
+			t.Errorf("request = %s %s, want POST /v3/mail/send", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer SG.test" {
+			t.Errorf("Authorization = %q, want %q", auth, "Bearer SG.test")
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want %q", ct, "application/json")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("X-Message-Id", "sg-message-1")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	repo := NewSendGridRepository(testConfig(), config.ProviderConfig{
+		Name:     "sendgrid",
+		Email:    "noreply@example.com",
+		APIKey:   "SG.test",
+		Endpoint: server.URL + "/v3/mail/send",
+	}, testTemplates(t))
+
+	receipt, err := repo.Send(testContact())
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if receipt.Provider != "sendgrid" || receipt.MessageID != "sg-message-1" {
+		t.Errorf("Send() = %+v, want provider %q and message ID %q", receipt, "sendgrid", "sg-message-1")
+	}
+
+	if len(got.Personalizations) != 1 || len(got.Personalizations[0].To) != 1 ||
+		got.Personalizations[0].To[0].Email != "owner@example.com" {
+		t.Errorf("personalizations = %+v, want one to owner@example.com", got.Personalizations)
+	}
+	if got.From.Email != "noreply@example.com" {
+		t.Errorf("from = %q, want %q", got.From.Email, "noreply@example.com")
+	}
+	if got.ReplyTo == nil || got.ReplyTo.Email != "john.doe@example.com" {
+		t.Errorf("reply_to = %+v, want john.doe@example.com", got.ReplyTo)
+	}
+	if got.Subject != "[Portfolio Contact] Order #42" {
+		t.Errorf("subject = %q, want %q", got.Subject, "[Portfolio Contact] Order #42")
+	}
+	if len(got.Content) != 2 || got.Content[0].Type != "text/plain" || got.Content[1].Type != "text/html" {
+		t.Errorf("content = %+v, want text/plain then text/html", got.Content)
+	}
+}
+
+func TestSendGridRepositoryErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		wantPermanent bool
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, wantPermanent: false},
+		{name: "server error", status: http.StatusInternalServerError, wantPermanent: false},
+		{name: "unavailable", status: http.StatusServiceUnavailable, wantPermanent: false},
+		{name: "bad request", status: http.StatusBadRequest, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"errors":[{"message":"rejected"}]}`))
+			})
+
+			repo := NewSendGridRepository(testConfig(), config.ProviderConfig{
+				Name:     "sendgrid",
+				Email:    "noreply@example.com",
+				APIKey:   "SG.test",
+				Endpoint: server.URL,
+			}, testTemplates(t))
+
+			_, err := repo.Send(testContact())
+			if err == nil {
+				t.Fatalf("Send() error = nil, want status %d", tt.status)
+			}
+			if got := repository.IsPermanent(err); got != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, got, tt.wantPermanent)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// sesService is the SigV4 signing name of Amazon SES
+const sesService = "ses"
+
+// sesPath is the SES v2 SendEmail operation path
+const sesPath = "/v2/email/outbound-emails"
+
+// sesThrottlingErrors are SES error types that are worth retrying
+var sesThrottlingErrors = map[string]bool{
+	"TooManyRequestsException": true,
+	"LimitExceededException":   true,
+	"ThrottlingException":      true,
+}
+
+// sesRepository implements EmailRepository using the Amazon SES v2 SendEmail API
+type sesRepository struct {
+	name        string
+	endpoint    string
+	region      string
+	credentials awsCredentials
+	client      *http.Client
+	templates   *Templates
+	senderEmail string
+	recipients  entity.Recipients
+}
+
+// sesContent is a text part of an SES message
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+// sesHeader is a custom header of a simple SendEmail message
+type sesHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// sesRequest is the SendEmail request body
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses  []string `json:"ToAddresses"`
+		CcAddresses  []string `json:"CcAddresses,omitempty"`
+		BccAddresses []string `json:"BccAddresses,omitempty"`
+	} `json:"Destination"`
+	ReplyToAddresses []string `json:"ReplyToAddresses,omitempty"`
+	Content          struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				HTML sesContent `json:"Html"`
+				Text sesContent `json:"Text"`
+			} `json:"Body"`
+			Headers []sesHeader `json:"Headers,omitempty"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// sesResponse is the body of a successful SendEmail response
+type sesResponse struct {
+	MessageID string `json:"MessageId"`
+}
+
+// sesErrorResponse is the body of an SES error response
+type sesErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewSESRepository creates a new Amazon SES email repository for the given provider
+func NewSESRepository(cfg *config.Config, provider config.ProviderConfig, templates *Templates) repository.EmailRepository {
+	endpoint := provider.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://email.%s.amazonaws.com", provider.Region)
+	}
+
+	return &sesRepository{
+		name:     provider.Name,
+		endpoint: strings.TrimRight(endpoint, "/"),
+		region:   provider.Region,
+		credentials: awsCredentials{
+			AccessKeyID:     provider.AccessKeyID,
+			SecretAccessKey: provider.SecretAccessKey,
+			SessionToken:    provider.SessionToken,
+		},
+		client:      newHTTPClient(),
+		templates:   templates,
+		senderEmail: provider.Email,
+		recipients:  cfg.Recipients(),
+	}
+}
+
+// Send sends an email based on contact information
+func (r *sesRepository) Send(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newNotification(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[SESRepository] Provider %s failed to render email: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// SendConfirmation sends the submitter a confirmation with a copy of their message
+func (r *sesRepository) SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newConfirmation(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[SESRepository] Provider %s failed to render confirmation: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// send delivers a rendered message and returns the provider's receipt
+func (r *sesRepository) send(msg *message) (*entity.DeliveryReceipt, error) {
+	var body sesRequest
+	body.FromEmailAddress = msg.From
+	body.Destination.ToAddresses = msg.To
+	body.Destination.CcAddresses = msg.CC
+	body.Destination.BccAddresses = msg.BCC
+	if msg.ReplyTo != "" {
+		body.ReplyToAddresses = []string{msg.ReplyTo}
+	}
+	body.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	body.Content.Simple.Body.HTML = sesContent{Data: msg.HTML, Charset: "UTF-8"}
+	body.Content.Simple.Body.Text = sesContent{Data: msg.Text, Charset: "UTF-8"}
+	for name, value := range msg.Headers {
+		body.Content.Simple.Headers = append(body.Content.Simple.Headers, sesHeader{Name: name, Value: value})
+	}
+
+	payload, err := json.Marshal(&body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode request: %w", repository.ErrPermanentFailure, err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, r.endpoint+sesPath, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %w", repository.ErrPermanentFailure, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	signRequest(req, payload, r.credentials, r.region, sesService, time.Now())
+
+	_, respBody, err := doRequest(r.client, req, sesError)
+	if err != nil {
+		log.Printf("[SESRepository] Provider %s failed to send email: %v", r.name, entity.RedactAddresses(err.Error()))
+		return nil, fmt.Errorf("failed to send email: %w", err)
+	}
+
+	// The message was accepted; a missing ID in the response is not an error
+	var result sesResponse
+	_ = json.Unmarshal(respBody, &result)
+	messageID := result.MessageID
+
+	log.Printf("[SESRepository] Provider %s sent email successfully (message ID %s)", r.name, messageID)
+	return &entity.DeliveryReceipt{Provider: r.name, MessageID: messageID}, nil
+}
+
+// sesError maps an SES error response. SES names the error in the
+// X-Amzn-ErrorType header; throttling errors are retried even on 400.
+func sesError(resp *http.Response, body []byte) error {
+	errorType, _, _ := strings.Cut(resp.Header.Get("X-Amzn-ErrorType"), ":")
+
+	var errResp sesErrorResponse
+	detail := truncate(string(body))
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Message != "" {
+		detail = truncate(errResp.Message)
+	}
+	if errorType != "" {
+		detail = errorType + ": " + detail
+	}
+
+	if sesThrottlingErrors[errorType] {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	return classifyStatus(resp.StatusCode, detail)
+}
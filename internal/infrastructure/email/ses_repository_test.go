@@ -0,0 +1,166 @@
+package email
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/config"
+)
+
+// TestSESRepositorySend sends through a fake SES endpoint that checks the
+// SendEmail request and its signature, then replies with each outcome
+func TestSESRepositorySend(t *testing.T) {
+	creds := awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		SessionToken:    "session-token",
+	}
+
+	tests := []struct {
+		name          string
+		status        int
+		errorType     string
+		body          string
+		wantMessageID string
+		wantPermanent bool
+	}{
+		{
+			name:          "sent",
+			status:        http.StatusOK,
+			body:          `{"MessageId":"ses-message-1"}`,
+			wantMessageID: "ses-message-1",
+		},
+		{
+			name:          "throttled",
+			status:        http.StatusBadRequest,
+			errorType:     "TooManyRequestsException:http://internal.amazon.com/coral/com.amazon.coral.availability/",
+			body:          `{"message":"Maximum sending rate exceeded."}`,
+			wantPermanent: false,
+		},
+		{
+			name:          "rejected",
+			status:        http.StatusBadRequest,
+			errorType:     "MessageRejected",
+			body:          `{"message":"Email address is not verified."}`,
+			wantPermanent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("failed to read request: %v", err)
+				}
+				if r.Method != http.MethodPost || r.URL.Path != sesPath {
+					t.Errorf("request = %s %s, want POST %s", r.Method, r.URL.Path, sesPath)
+				}
+				checkSESSignature(t, r, body, creds)
+
+				var got sesRequest
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				if got.FromEmailAddress != "noreply@example.com" {
+					t.Errorf("FromEmailAddress = %q, want %q", got.FromEmailAddress, "noreply@example.com")
+				}
+				if len(got.Destination.ToAddresses) != 1 || got.Destination.ToAddresses[0] != "owner@example.com" {
+					t.Errorf("ToAddresses = %v, want [owner@example.com]", got.Destination.ToAddresses)
+				}
+				if len(got.ReplyToAddresses) != 1 || got.ReplyToAddresses[0] != "john.doe@example.com" {
+					t.Errorf("ReplyToAddresses = %v, want [john.doe@example.com]", got.ReplyToAddresses)
+				}
+				simple := got.Content.Simple
+				if simple.Subject.Data != "[Portfolio Contact] Order #42" {
+					t.Errorf("Subject = %q, want %q", simple.Subject.Data, "[Portfolio Contact] Order #42")
+				}
+				if simple.Body.Text.Data == "" || simple.Body.HTML.Data == "" {
+					t.Errorf("Body Text and Html must both be set")
+				}
+
+				if tt.errorType != "" {
+					w.Header().Set("X-Amzn-ErrorType", tt.errorType)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			repo := NewSESRepository(testConfig(), config.ProviderConfig{
+				Name:            "ses",
+				Email:           "noreply@example.com",
+				Region:          "eu-west-1",
+				AccessKeyID:     creds.AccessKeyID,
+				SecretAccessKey: creds.SecretAccessKey,
+				SessionToken:    creds.SessionToken,
+				Endpoint:        server.URL,
+			}, testTemplates(t))
+
+			receipt, err := repo.Send(testContact())
+			if tt.wantMessageID != "" {
+				if err != nil {
+					t.Fatalf("Send() error = %v", err)
+				}
+				if receipt.Provider != "ses" || receipt.MessageID != tt.wantMessageID {
+					t.Errorf("Send() = %+v, want provider %q and message ID %q", receipt, "ses", tt.wantMessageID)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Send() error = nil, want %s", tt.errorType)
+			}
+			if got := repository.IsPermanent(err); got != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, got, tt.wantPermanent)
+			}
+		})
+	}
+}
+
+// checkSESSignature re-signs the received request with the headers it claims
+// to have signed and compares the result with its Authorization header
+func checkSESSignature(t *testing.T, r *http.Request, body []byte, creds awsCredentials) {
+	t.Helper()
+
+	auth := r.Header.Get("Authorization")
+	wantPrefix := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"
+	if !strings.HasPrefix(auth, wantPrefix) || !strings.Contains(auth, "/eu-west-1/ses/aws4_request, ") {
+		t.Errorf("Authorization = %q, want an eu-west-1 ses credential scope", auth)
+		return
+	}
+	_, signed, _ := strings.Cut(auth, "SignedHeaders=")
+	signed, _, _ = strings.Cut(signed, ",")
+	if signed != "content-type;host;x-amz-date;x-amz-security-token" {
+		t.Errorf("SignedHeaders = %q, want %q", signed, "content-type;host;x-amz-date;x-amz-security-token")
+	}
+	if token := r.Header.Get("X-Amz-Security-Token"); token != creds.SessionToken {
+		t.Errorf("X-Amz-Security-Token = %q, want %q", token, creds.SessionToken)
+	}
+
+	now, err := time.Parse(sigV4DateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		t.Errorf("X-Amz-Date = %q: %v", r.Header.Get("X-Amz-Date"), err)
+		return
+	}
+
+	resigned, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	for _, name := range strings.Split(signed, ";") {
+		if name != "host" {
+			resigned.Header.Set(name, r.Header.Get(name))
+		}
+	}
+	signRequest(resigned, body, creds, "eu-west-1", sesService, now)
+
+	if got := resigned.Header.Get("Authorization"); got != auth {
+		t.Errorf("Authorization =\n%s\nwant\n%s", auth, got)
+	}
+}
@@ -1,9 +1,13 @@
 package email
 
 import (
+	"errors"
 	"fmt"
-	"html"
 	"log"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
 	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
@@ -14,163 +18,119 @@ import (
 
 // smtpRepository implements EmailRepository using SMTP
 type smtpRepository struct {
-	dialer        *gomail.Dialer
-	senderEmail   string
-	receiverEmail string
+	name        string
+	dialer      *gomail.Dialer
+	templates   *Templates
+	senderEmail string
+	recipients  entity.Recipients
 }
 
-// NewSMTPRepository creates a new SMTP email repository
-func NewSMTPRepository(cfg *config.Config) repository.EmailRepository {
+// NewSMTPRepository creates a new SMTP email repository for the given provider
+func NewSMTPRepository(cfg *config.Config, provider config.ProviderConfig, templates *Templates) repository.EmailRepository {
 	dialer := gomail.NewDialer(
-		cfg.SMTPHost,
-		cfg.SMTPPort,
-		cfg.SMTPUsername,
-		cfg.SMTPPassword,
+		provider.Host,
+		provider.Port,
+		provider.Username,
+		provider.Password,
 	)
 
 	return &smtpRepository{
-		dialer:        dialer,
-		senderEmail:   cfg.SMTPEmail,
-		receiverEmail: cfg.ReceiverEmail,
+		name:        provider.Name,
+		dialer:      dialer,
+		templates:   templates,
+		senderEmail: provider.Email,
+		recipients:  cfg.Recipients(),
 	}
 }
 
 // Send sends an email based on contact information
-func (r *smtpRepository) Send(contact *entity.Contact) error {
-	// Sanitize input to prevent XSS in email clients
-	name := html.EscapeString(contact.Name)
-	email := html.EscapeString(contact.Email)
-	subject := html.EscapeString(contact.Subject)
-	message := html.EscapeString(contact.Message)
+func (r *smtpRepository) Send(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newNotification(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[SMTPRepository] Provider %s failed to render email: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
+
+// SendConfirmation sends the submitter a confirmation with a copy of their message
+func (r *smtpRepository) SendConfirmation(contact *entity.Contact) (*entity.DeliveryReceipt, error) {
+	msg, err := newConfirmation(r.templates, r.senderEmail, r.recipients, contact)
+	if err != nil {
+		log.Printf("[SMTPRepository] Provider %s failed to render confirmation: %v", r.name, err)
+		return nil, fmt.Errorf("%w: failed to render email: %w", repository.ErrPermanentFailure, err)
+	}
+	return r.send(msg)
+}
 
-	// Build email body
-	htmlBody := r.buildEmailTemplate(name, email, subject, message)
+// send delivers a rendered message and returns the provider's receipt
+func (r *smtpRepository) send(msg *message) (*entity.DeliveryReceipt, error) {
+	// SMTP relays don't report an ID, so the message carries its own
+	messageID := newMessageID(msg.From)
 
 	// Create email message
 	m := gomail.NewMessage()
-	m.SetHeader("From", r.senderEmail)
-	m.SetHeader("To", r.receiverEmail)
-	m.SetHeader("Subject", fmt.Sprintf("[Portfolio Contact] %s", contact.Subject))
-	m.SetHeader("Reply-To", contact.Email)
-	m.SetBody("text/html", htmlBody)
+	m.SetHeader("Message-ID", messageID)
+	m.SetHeader("From", msg.From)
+	m.SetHeader("To", msg.To...)
+	if len(msg.CC) > 0 {
+		m.SetHeader("Cc", msg.CC...)
+	}
+	if len(msg.BCC) > 0 {
+		// gomail adds Bcc recipients to the envelope and strips the header
+		m.SetHeader("Bcc", msg.BCC...)
+	}
+	m.SetHeader("Subject", msg.Subject)
+	if msg.ReplyTo != "" {
+		m.SetHeader("Reply-To", msg.ReplyTo)
+	}
+	for name, value := range msg.Headers {
+		m.SetHeader(name, value)
+	}
+	// multipart/alternative: plain text first, HTML as the preferred part
+	m.SetBody("text/plain", msg.Text)
+	m.AddAlternative("text/html", msg.HTML)
 
 	// Send email
 	if err := r.dialer.DialAndSend(m); err != nil {
-		log.Printf("[SMTPRepository] Failed to send email: %v", err)
-		return fmt.Errorf("failed to send email: %w", err)
+		log.Printf("[SMTPRepository] Provider %s failed to send email: %v", r.name, entity.RedactAddresses(err.Error()))
+		return nil, classifySMTPError(fmt.Errorf("failed to send email: %w", err))
 	}
 
-	log.Printf("[SMTPRepository] Email sent successfully to %s", r.receiverEmail)
-	return nil
+	log.Printf("[SMTPRepository] Provider %s sent email successfully (message ID %s)", r.name, messageID)
+	return &entity.DeliveryReceipt{Provider: r.name, MessageID: messageID}, nil
 }
 
-// buildEmailTemplate creates a beautiful HTML email template
-func (r *smtpRepository) buildEmailTemplate(name, email, subject, message string) string {
-	return fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <style>
-        body { 
-            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, sans-serif; 
-            line-height: 1.6; 
-            color: #333; 
-            margin: 0;
-            padding: 0;
-            background-color: #f5f5f5;
-        }
-        .container { 
-            max-width: 600px; 
-            margin: 20px auto; 
-            background: white;
-            border-radius: 12px;
-            overflow: hidden;
-            box-shadow: 0 4px 6px rgba(0, 0, 0, 0.1);
-        }
-        .header { 
-            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%); 
-            color: white; 
-            padding: 30px 20px; 
-            text-align: center;
-        }
-        .header h2 {
-            margin: 0;
-            font-size: 24px;
-            font-weight: 600;
-        }
-        .content { 
-            padding: 30px; 
-        }
-        .field { 
-            margin-bottom: 20px; 
-            padding: 15px;
-            background: #f8f9fa;
-            border-radius: 8px;
-            border-left: 4px solid #667eea;
-        }
-        .label { 
-            font-weight: 600; 
-            color: #667eea; 
-            font-size: 12px;
-            text-transform: uppercase;
-            letter-spacing: 0.5px;
-            margin-bottom: 5px;
-        }
-        .value { 
-            color: #333;
-            font-size: 15px;
-        }
-        .value a {
-            color: #667eea;
-            text-decoration: none;
-        }
-        .message-box {
-            background: #f8f9fa;
-            padding: 20px;
-            border-radius: 8px;
-            border-left: 4px solid #764ba2;
-            white-space: pre-wrap;
-        }
-        .footer { 
-            padding: 20px; 
-            font-size: 12px; 
-            color: #888; 
-            text-align: center; 
-            background: #f8f9fa;
-            border-top: 1px solid #eee;
-        }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <h2>📧 New Contact Form Submission</h2>
-        </div>
-        <div class="content">
-            <div class="field">
-                <div class="label">From</div>
-                <div class="value">%s</div>
-            </div>
-            <div class="field">
-                <div class="label">Email</div>
-                <div class="value"><a href="mailto:%s">%s</a></div>
-            </div>
-            <div class="field">
-                <div class="label">Subject</div>
-                <div class="value">%s</div>
-            </div>
-            <div class="field">
-                <div class="label">Message</div>
-                <div class="message-box">%s</div>
-            </div>
-        </div>
-        <div class="footer">
-            This email was sent from your portfolio contact form.
-        </div>
-    </div>
-</body>
-</html>
-`, name, email, email, subject, message)
+// smtpReplyCode matches the reply code of an SMTP error that gomail flattened into a string
+var smtpReplyCode = regexp.MustCompile(`(?:^|: )([245]\d{2})[ -]`)
+
+// smtpAuthCodes are 5xx replies about the relay's credentials rather than the
+// message: authentication required (530), mechanism too weak (534) and
+// authentication failed (535). Another provider may still deliver the message.
+var smtpAuthCodes = map[int]bool{530: true, 534: true, 535: true}
+
+// classifySMTPError marks 5xx SMTP replies about the message or recipient as
+// permanent; 4xx replies, authentication failures and network errors stay
+// transient so they can be retried or delivered by another provider
+func classifySMTPError(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		if protoErr.Code >= 500 && !smtpAuthCodes[protoErr.Code] {
+			return fmt.Errorf("%w: %w", repository.ErrPermanentFailure, err)
+		}
+		return err
+	}
+
+	msg := err.Error()
+	if m := smtpReplyCode.FindStringSubmatch(msg); m != nil {
+		if code, _ := strconv.Atoi(m[1]); code >= 500 && !smtpAuthCodes[code] {
+			return fmt.Errorf("%w: %w", repository.ErrPermanentFailure, err)
+		}
+		return err
+	}
+	if strings.Contains(msg, "gomail: invalid") {
+		return fmt.Errorf("%w: %w", repository.ErrPermanentFailure, err)
+	}
+
+	return err
 }
@@ -0,0 +1,221 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+// defaultTemplates holds the built-in email templates
+//
+//go:embed templates/*
+var defaultTemplates embed.FS
+
+// Template names; each has <name>.subject.txt, <name>.txt and <name>.html files
+const (
+	templateNotification = "notification"
+	templateConfirmation = "confirmation"
+)
+
+// templateNames lists every template loaded by Templates
+var templateNames = []string{templateNotification, templateConfirmation}
+
+// reloadInterval is the minimum time between checks for changed template files
+const reloadInterval = 2 * time.Second
+
+// templateSet is a parsed subject, text and HTML template
+type templateSet struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// rendered is the output of a template set
+type rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Templates renders emails from html/template and text/template files.
+// Files in dir override the embedded defaults and are reloaded when they change.
+type Templates struct {
+	dir string
+
+	mu          sync.RWMutex
+	sets        map[string]*templateSet
+	fingerprint string
+	lastCheck   time.Time
+}
+
+// NewTemplates loads the email templates, overriding the defaults with files in dir (optional)
+func NewTemplates(dir string) (*Templates, error) {
+	t := &Templates{dir: dir}
+
+	sets, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	t.sets = sets
+	t.fingerprint = t.currentFingerprint()
+	t.lastCheck = time.Now()
+	return t, nil
+}
+
+// Render executes the named template set with data
+func (t *Templates) Render(name string, data any) (*rendered, error) {
+	t.reloadIfChanged()
+
+	t.mu.RLock()
+	set, ok := t.sets[name]
+	t.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := set.subject.Execute(&subject, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s subject: %w", name, err)
+	}
+	if err := set.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", name, err)
+	}
+	if err := set.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s html: %w", name, err)
+	}
+
+	return &rendered{
+		Subject: singleLine(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// reloadIfChanged re-parses the templates when files in dir have changed.
+// A broken template is logged and the previous version is kept.
+func (t *Templates) reloadIfChanged() {
+	if t.dir == "" {
+		return
+	}
+
+	// Most calls fall inside the interval, so check it without blocking renders
+	t.mu.RLock()
+	due := time.Since(t.lastCheck) >= reloadInterval
+	t.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Another caller may have checked while we waited for the lock
+	if time.Since(t.lastCheck) < reloadInterval {
+		return
+	}
+	t.lastCheck = time.Now()
+
+	fingerprint := t.currentFingerprint()
+	if fingerprint == t.fingerprint {
+		return
+	}
+
+	sets, err := t.parse()
+	if err != nil {
+		log.Printf("[Templates] Keeping previous templates, reload failed: %v", err)
+		return
+	}
+
+	t.sets = sets
+	t.fingerprint = fingerprint
+	log.Printf("[Templates] Reloaded email templates from %s", t.dir)
+}
+
+// parse parses every template set
+func (t *Templates) parse() (map[string]*templateSet, error) {
+	sets := make(map[string]*templateSet, len(templateNames))
+
+	for _, name := range templateNames {
+		subject, err := t.read(name + ".subject.txt")
+		if err != nil {
+			return nil, err
+		}
+		text, err := t.read(name + ".txt")
+		if err != nil {
+			return nil, err
+		}
+		html, err := t.read(name + ".html")
+		if err != nil {
+			return nil, err
+		}
+
+		set := &templateSet{}
+		if set.subject, err = texttemplate.New(name + ".subject.txt").Parse(subject); err != nil {
+			return nil, err
+		}
+		if set.text, err = texttemplate.New(name + ".txt").Parse(text); err != nil {
+			return nil, err
+		}
+		if set.html, err = htmltemplate.New(name + ".html").Parse(html); err != nil {
+			return nil, err
+		}
+		sets[name] = set
+	}
+
+	return sets, nil
+}
+
+// read returns a template file from dir, falling back to the embedded default
+func (t *Templates) read(file string) (string, error) {
+	if t.dir != "" {
+		data, err := os.ReadFile(filepath.Join(t.dir, file))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read template %s: %w", file, err)
+		}
+	}
+
+	data, err := defaultTemplates.ReadFile("templates/" + file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read default template %s: %w", file, err)
+	}
+	return string(data), nil
+}
+
+// currentFingerprint summarises the size and modification time of the template files in dir
+func (t *Templates) currentFingerprint() string {
+	if t.dir == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, name := range templateNames {
+		for _, file := range []string{name + ".subject.txt", name + ".txt", name + ".html"} {
+			info, err := os.Stat(filepath.Join(t.dir, file))
+			if err != nil {
+				b.WriteString(file + ":-;")
+				continue
+			}
+			fmt.Fprintf(&b, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String()
+}
+
+// singleLine collapses a rendered subject onto one line to prevent header injection
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
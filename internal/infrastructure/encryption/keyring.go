@@ -0,0 +1,166 @@
+package encryption
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IndexKeyID is the reserved key ID of the HMAC key used for blind indexes
+const IndexKeyID = "index"
+
+// keySize is the AES-256 key length in bytes
+const keySize = 32
+
+// Keyring errors
+var (
+	ErrNoDataKeys    = errors.New("no encryption keys configured besides the index key")
+	ErrNoIndexKey    = errors.New("an \"index\" key is required for the email index")
+	ErrUnknownKey    = errors.New("unknown encryption key")
+	ErrInvalidSealed = errors.New("invalid encrypted value")
+)
+
+// Keyring encrypts values with AES-256-GCM under the active key and decrypts
+// them with whichever key they were written with, so old keys can be rotated
+// out. A nil *Keyring means encryption is disabled.
+type Keyring struct {
+	activeID string
+	aeads    map[string]cipher.AEAD
+	indexKey []byte
+}
+
+// NewKeyring builds a keyring from "id:base64key" entries, given as a
+// comma-separated list and/or one per line in file ('#' starts a comment).
+// New values are encrypted with activeID, or the last key listed when empty.
+// It returns nil when no keys are configured.
+func NewKeyring(keys, file, activeID string) (*Keyring, error) {
+	entries := splitEntries(keys, ",")
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption keys: %w", err)
+		}
+		entries = append(entries, splitEntries(string(data), "\n")...)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	k := &Keyring{aeads: make(map[string]cipher.AEAD)}
+	lastID := ""
+	for _, entry := range entries {
+		id, encoded, ok := strings.Cut(entry, ":")
+		id = strings.TrimSpace(id)
+		if !ok || id == "" {
+			return nil, errors.New("encryption key entry must be id:base64key")
+		}
+
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("encryption key %q must be %d bytes, base64 encoded", id, keySize)
+		}
+
+		if id == IndexKeyID {
+			k.indexKey = key
+			continue
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		if k.aeads[id], err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+		lastID = id
+	}
+
+	if len(k.aeads) == 0 {
+		return nil, ErrNoDataKeys
+	}
+	if k.indexKey == nil {
+		return nil, ErrNoIndexKey
+	}
+
+	k.activeID = activeID
+	if k.activeID == "" {
+		k.activeID = lastID
+	}
+	if _, ok := k.aeads[k.activeID]; !ok {
+		return nil, fmt.Errorf("%w %q set as active", ErrUnknownKey, k.activeID)
+	}
+
+	return k, nil
+}
+
+// ActiveKeyID returns the ID of the key new values are encrypted with
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Encrypt seals plaintext with the active key. aad is authenticated but not
+// stored, binding the ciphertext to its context (e.g. record ID and field).
+func (k *Keyring) Encrypt(plaintext, aad string) (string, error) {
+	aead := k.aeads[k.activeID]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(aad))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a value sealed by Encrypt with the given key and aad
+func (k *Keyring) Decrypt(keyID, ciphertext, aad string) (string, error) {
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", ErrInvalidSealed
+	}
+
+	nonce, body := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, body, []byte(aad))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSealed, err)
+	}
+	return string(plaintext), nil
+}
+
+// BlindIndex returns a keyed hash of value for equality lookups on encrypted
+// data. Values are trimmed and lowercased so lookups are case-insensitive.
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// splitEntries splits s by sep, dropping blanks and '#' comments
+func splitEntries(s, sep string) []string {
+	var entries []string
+	scanner := bufio.NewScanner(strings.NewReader(strings.ReplaceAll(s, sep, "\n")))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,152 @@
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMaxLength caps string fields that declare no max_length, so an
+// unbounded field cannot carry more than the built-in form's message
+const defaultMaxLength = entity.MaxMessageLength
+
+// fileDefinition is the top level of a forms config file
+type fileDefinition struct {
+	Forms []formDefinition `json:"forms" yaml:"forms"`
+}
+
+// formDefinition is a form as declared in the config file
+type formDefinition struct {
+	ID     string            `json:"id" yaml:"id"`
+	Name   string            `json:"name" yaml:"name"`
+	To     []string          `json:"to" yaml:"to"`
+	CC     []string          `json:"cc" yaml:"cc"`
+	BCC    []string          `json:"bcc" yaml:"bcc"`
+	Fields []fieldDefinition `json:"fields" yaml:"fields"`
+}
+
+// fieldDefinition is a form field as declared in the config file
+type fieldDefinition struct {
+	Name      string   `json:"name" yaml:"name"`
+	Label     string   `json:"label" yaml:"label"`
+	Type      string   `json:"type" yaml:"type"`
+	Required  bool     `json:"required" yaml:"required"`
+	MinLength int      `json:"min_length" yaml:"min_length"`
+	MaxLength int      `json:"max_length" yaml:"max_length"`
+	Pattern   string   `json:"pattern" yaml:"pattern"`
+	Options   []string `json:"options" yaml:"options"`
+}
+
+// fileRepository implements FormRepository with forms loaded from a config file
+type fileRepository struct {
+	forms []*entity.Form
+	byID  map[string]*entity.Form
+}
+
+// NewFileFormRepository loads form definitions from a JSON or YAML file.
+// An empty path yields a repository without forms.
+func NewFileFormRepository(path string) (repository.FormRepository, error) {
+	r := &fileRepository{byID: make(map[string]*entity.Form)}
+	if path == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read forms config: %w", err)
+	}
+
+	var def fileDefinition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &def)
+	default:
+		err = json.Unmarshal(data, &def)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse forms config %s: %w", path, err)
+	}
+
+	for _, fd := range def.Forms {
+		form, err := fd.toForm()
+		if err != nil {
+			return nil, fmt.Errorf("invalid form %q: %w", fd.ID, err)
+		}
+		if _, ok := r.byID[form.ID]; ok {
+			return nil, fmt.Errorf("invalid form %q: declared twice", form.ID)
+		}
+
+		r.forms = append(r.forms, form)
+		r.byID[form.ID] = form
+	}
+
+	return r, nil
+}
+
+// Get returns the form with the given ID
+func (r *fileRepository) Get(id string) (*entity.Form, error) {
+	form, ok := r.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: form %s", repository.ErrNotFound, id)
+	}
+	return form, nil
+}
+
+// List returns every configured form in file order
+func (r *fileRepository) List() []*entity.Form {
+	return r.forms
+}
+
+// toForm converts a definition into a validated domain entity
+func (d *formDefinition) toForm() (*entity.Form, error) {
+	form := &entity.Form{
+		ID:         d.ID,
+		Name:       d.Name,
+		Recipients: entity.NewRecipients(d.To, d.CC, d.BCC),
+	}
+	if form.Name == "" {
+		form.Name = d.ID
+	}
+
+	for _, fd := range d.Fields {
+		field := entity.FormField{
+			Name:      fd.Name,
+			Label:     fd.Label,
+			Type:      entity.FieldType(strings.ToLower(fd.Type)),
+			Required:  fd.Required,
+			MinLength: fd.MinLength,
+			MaxLength: fd.MaxLength,
+			Options:   fd.Options,
+		}
+		if field.Type == "" {
+			field.Type = entity.FieldString
+		}
+		if field.Type == entity.FieldString && field.MaxLength == 0 {
+			field.MaxLength = defaultMaxLength
+		}
+
+		if fd.Pattern != "" {
+			// Anchor the pattern so it has to match the whole value
+			pattern, err := regexp.Compile(`^(?:` + fd.Pattern + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("field %s has an invalid pattern: %w", fd.Name, err)
+			}
+			field.Pattern = pattern
+		}
+
+		form.Fields = append(form.Fields, field)
+	}
+
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+	return form, nil
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltDeadLetters implements DeadLetterRepository over the failed outbox records
+type boltDeadLetters struct {
+	db   *bolt.DB
+	keys *encryption.Keyring
+}
+
+// NewBoltDeadLetters creates a new dead letter repository backed by db
+func NewBoltDeadLetters(db *bolt.DB, keys *encryption.Keyring) (repository.DeadLetterRepository, error) {
+	if err := createBucket(db); err != nil {
+		return nil, err
+	}
+
+	return &boltDeadLetters{db: db, keys: keys}, nil
+}
+
+// List returns all dead letters, oldest first
+func (d *boltDeadLetters) List() ([]*entity.DeadLetter, error) {
+	var letters []*entity.DeadLetter
+
+	err := d.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).ForEach(func(_, v []byte) error {
+			rec, err := decode(d.keys, v)
+			if err != nil {
+				return err
+			}
+			if rec.Status == statusFailed {
+				letters = append(letters, &entity.DeadLetter{
+					Contact:  rec.ToContact(),
+					Reason:   rec.Reason,
+					Attempts: rec.Attempts,
+					FailedAt: rec.UpdatedAt,
+				})
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letters: %w", err)
+	}
+
+	return letters, nil
+}
+
+// Requeue moves a dead letter back to pending and returns it as it was before
+func (d *boltDeadLetters) Requeue(id string) (*entity.DeadLetter, error) {
+	var letter *entity.DeadLetter
+
+	err := d.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		rec, err := get(b, d.keys, id)
+		if err != nil {
+			return err
+		}
+		if rec.Status != statusFailed {
+			return fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+		}
+
+		letter = &entity.DeadLetter{
+			Contact:  rec.ToContact(),
+			Reason:   rec.Reason,
+			Attempts: rec.Attempts,
+			FailedAt: rec.UpdatedAt,
+		}
+
+		rec.Status = statusPending
+		rec.UpdatedAt = time.Now().UTC()
+		return put(b, d.keys, rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return letter, nil
+}
+
+// DeleteBefore removes dead letters submitted before the given time
+func (d *boltDeadLetters) DeleteBefore(before time.Time) (int, error) {
+	ids, err := deleteWhere(d.db, func(rec *record) bool {
+		return rec.Status == statusFailed && rec.SubmittedAt.Before(before)
+	})
+	return len(ids), err
+}
@@ -0,0 +1,245 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/storage"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketName is the bucket holding outbox records keyed by contact ID
+var bucketName = []byte("outbox")
+
+// Record statuses
+const (
+	statusPending = "pending"
+	statusFailed  = "failed"
+)
+
+// record is the persisted form of an outbox entry
+type record struct {
+	storage.ContactRecord
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	Attempts  int       `json:"attempts,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// boltOutbox implements OutboxRepository using an embedded bbolt database.
+// Sent records are removed; failed records stay behind as dead letters.
+// Personal fields are encrypted with keys unless it is nil.
+type boltOutbox struct {
+	db   *bolt.DB
+	keys *encryption.Keyring
+}
+
+// NewBoltOutbox creates a new outbox repository backed by db
+func NewBoltOutbox(db *bolt.DB, keys *encryption.Keyring) (repository.OutboxRepository, error) {
+	if err := createBucket(db); err != nil {
+		return nil, err
+	}
+
+	return &boltOutbox{db: db, keys: keys}, nil
+}
+
+// Add records a contact that is about to be delivered
+func (o *boltOutbox) Add(contact *entity.Contact) error {
+	rec := &record{
+		ContactRecord: storage.NewContactRecord(contact),
+		Status:        statusPending,
+		UpdatedAt:     time.Now().UTC(),
+	}
+
+	return o.db.Update(func(tx *bolt.Tx) error {
+		return put(tx.Bucket(bucketName), o.keys, rec)
+	})
+}
+
+// MarkSent removes a delivered contact from the outbox
+func (o *boltOutbox) MarkSent(id string) error {
+	return o.Remove(id)
+}
+
+// Remove drops a contact that will not be delivered
+func (o *boltOutbox) Remove(id string) error {
+	return o.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Delete([]byte(id))
+	})
+}
+
+// MarkFailed moves a contact to the dead letters after the given number of attempts
+func (o *boltOutbox) MarkFailed(id string, reason string, attempts int) error {
+	return o.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		rec, err := get(b, o.keys, id)
+		if err != nil {
+			return err
+		}
+
+		rec.Status = statusFailed
+		rec.Reason = reason
+		rec.Attempts = attempts
+		rec.UpdatedAt = time.Now().UTC()
+		return put(b, o.keys, rec)
+	})
+}
+
+// Pending returns recorded contacts that were neither sent nor failed, oldest first
+func (o *boltOutbox) Pending() ([]*entity.Contact, error) {
+	var contacts []*entity.Contact
+
+	err := o.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).ForEach(func(_, v []byte) error {
+			rec, err := decode(o.keys, v)
+			if err != nil {
+				return err
+			}
+			if rec.Status == statusPending {
+				contacts = append(contacts, rec.ToContact())
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	return contacts, nil
+}
+
+// DeleteByEmail removes pending contacts and dead letters from the given submitter address
+func (o *boltOutbox) DeleteByEmail(email string) ([]string, error) {
+	return deleteWhere(o.db, func(rec *record) bool {
+		return rec.HasEmail(o.keys, email)
+	})
+}
+
+// Reencrypt rewrites every outbox record with the active key of keys and a
+// fresh email index, and returns how many records were rewritten
+func Reencrypt(db *bolt.DB, keys *encryption.Keyring) (int, error) {
+	count := 0
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		if b == nil {
+			return nil
+		}
+
+		var records []*record
+		err := b.ForEach(func(_ []byte, v []byte) error {
+			rec, err := decode(keys, v)
+			if err != nil {
+				return err
+			}
+			records = append(records, rec)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, rec := range records {
+			if err := put(b, keys, rec); err != nil {
+				return err
+			}
+		}
+		count = len(records)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-encrypt outbox: %w", err)
+	}
+
+	return count, nil
+}
+
+// deleteWhere removes every record for which match returns true and returns their IDs.
+// Records are matched without decrypting them.
+func deleteWhere(db *bolt.DB, match func(rec *record) bool) ([]string, error) {
+	var ids []string
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+
+		// Collect first; deleting while a cursor walks the bucket can skip keys
+		err := b.ForEach(func(k, v []byte) error {
+			var rec record
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			if match(&rec) {
+				ids = append(ids, string(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := b.Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete outbox records: %w", err)
+	}
+
+	return ids, nil
+}
+
+// createBucket ensures the outbox bucket exists
+func createBucket(db *bolt.DB) error {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create outbox bucket: %w", err)
+	}
+	return nil
+}
+
+// get loads and decrypts a record by ID
+func get(b *bolt.Bucket, keys *encryption.Keyring, id string) (*record, error) {
+	v := b.Get([]byte(id))
+	if v == nil {
+		return nil, fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+	}
+	return decode(keys, v)
+}
+
+// decode unmarshals and decrypts a stored record
+func decode(keys *encryption.Keyring, v []byte) (*record, error) {
+	var rec record
+	if err := json.Unmarshal(v, &rec); err != nil {
+		return nil, err
+	}
+	if err := rec.Open(keys, nil); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// put encrypts a copy of the record and stores it under its ID
+func put(b *bolt.Bucket, keys *encryption.Keyring, rec *record) error {
+	sealed := *rec
+	sealed.ContactRecord = rec.Copy()
+	if err := sealed.Seal(keys, nil); err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(&sealed)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(rec.ID), v)
+}
@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// Handler processes a single queued contact
+type Handler func(ctx context.Context, contact *entity.Contact) error
+
+// Config holds worker pool configuration
+type Config struct {
+	Workers int
+	Size    int
+}
+
+// WorkerPool implements DeliveryQueue using a buffered channel drained by a fixed number of workers
+type WorkerPool struct {
+	jobs    chan *entity.Contact
+	workers int
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.RWMutex
+	closed  bool
+	pending []*entity.Contact
+}
+
+// NewWorkerPool creates a new worker pool; call Start to begin processing
+func NewWorkerPool(cfg Config) *WorkerPool {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.Size < 1 {
+		cfg.Size = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &WorkerPool{
+		jobs:    make(chan *entity.Contact, cfg.Size),
+		workers: cfg.Workers,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start launches the workers, each calling handler for every dequeued contact
+func (p *WorkerPool) Start(handler Handler) {
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work(i+1, handler)
+	}
+	log.Printf("[Queue] Started %d workers (capacity %d)", p.workers, cap(p.jobs))
+}
+
+// Enqueue schedules a contact for delivery without blocking the caller
+func (p *WorkerPool) Enqueue(contact *entity.Contact) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return repository.ErrQueueClosed
+	}
+
+	select {
+	case p.jobs <- contact:
+		return nil
+	default:
+		return repository.ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting new jobs and waits until the queue is drained.
+// If ctx expires first, running handlers are cancelled and the jobs that
+// were never processed are returned in the error.
+func (p *WorkerPool) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancel()
+		log.Println("[Queue] All queued jobs delivered")
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+		<-done
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, contact := range p.pending {
+		log.Printf("[Queue] Undelivered job %s", contact.ID)
+	}
+	return fmt.Errorf("shutdown timed out with %d undelivered jobs: %w", len(p.pending), ctx.Err())
+}
+
+// work processes jobs until the channel is closed
+func (p *WorkerPool) work(id int, handler Handler) {
+	defer p.wg.Done()
+
+	for contact := range p.jobs {
+		// Once cancelled, keep draining so the remaining jobs can be reported
+		if p.ctx.Err() != nil {
+			p.markPending(contact)
+			continue
+		}
+
+		if err := handler(p.ctx, contact); err != nil {
+			log.Printf("[Queue] Worker %d failed job %s: %v", id, contact.ID, entity.RedactAddresses(err.Error()))
+		}
+	}
+}
+
+// markPending records a job that was not processed before shutdown
+func (p *WorkerPool) markPending(contact *entity.Contact) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, contact)
+}
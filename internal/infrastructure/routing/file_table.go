@@ -0,0 +1,150 @@
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+
+	"gopkg.in/yaml.v3"
+)
+
+// errConditionOperator is returned when a condition doesn't set exactly one operator
+var errConditionOperator = errors.New("condition must set exactly one of equals, contains, matches or in")
+
+// fileDefinition is the top level of a routing rules file
+type fileDefinition struct {
+	Default recipientsDefinition `json:"default" yaml:"default"`
+	Rules   []ruleDefinition     `json:"rules" yaml:"rules"`
+}
+
+// recipientsDefinition is a recipient list as declared in the file
+type recipientsDefinition struct {
+	To  []string `json:"to" yaml:"to"`
+	CC  []string `json:"cc" yaml:"cc"`
+	BCC []string `json:"bcc" yaml:"bcc"`
+}
+
+// ruleDefinition is a routing rule as declared in the file
+type ruleDefinition struct {
+	recipientsDefinition `yaml:",inline"`
+	Name                 string                `json:"name" yaml:"name"`
+	When                 []conditionDefinition `json:"when" yaml:"when"`
+	Continue             bool                  `json:"continue" yaml:"continue"`
+}
+
+// conditionDefinition is a rule condition as declared in the file
+type conditionDefinition struct {
+	Field    string   `json:"field" yaml:"field"`
+	Equals   string   `json:"equals" yaml:"equals"`
+	Contains string   `json:"contains" yaml:"contains"`
+	Matches  string   `json:"matches" yaml:"matches"`
+	In       []string `json:"in" yaml:"in"`
+}
+
+// NewFileRoutingTable loads routing rules from a JSON or YAML file. Contacts
+// that match no rule go to the file's default recipients, or fallback when the
+// file declares none. An empty path yields a table without rules.
+func NewFileRoutingTable(path string, fallback entity.Recipients) (*entity.RoutingTable, error) {
+	table := &entity.RoutingTable{Default: fallback}
+	if path == "" {
+		return table, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routing rules: %w", err)
+	}
+
+	var def fileDefinition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &def)
+	default:
+		err = json.Unmarshal(data, &def)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse routing rules %s: %w", path, err)
+	}
+
+	if d := def.Default.toRecipients(); !d.IsZero() {
+		if err := d.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid default route: %w", err)
+		}
+		table.Default = d
+	}
+
+	for _, rd := range def.Rules {
+		rule, err := rd.toRule()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", rd.Name, err)
+		}
+		table.Rules = append(table.Rules, *rule)
+	}
+
+	if err := table.Validate(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// toRecipients converts a definition into recipients
+func (d *recipientsDefinition) toRecipients() entity.Recipients {
+	return entity.NewRecipients(d.To, d.CC, d.BCC)
+}
+
+// toRule converts a definition into a domain rule
+func (d *ruleDefinition) toRule() (*entity.RoutingRule, error) {
+	rule := &entity.RoutingRule{
+		Name:       d.Name,
+		Recipients: d.toRecipients(),
+		Continue:   d.Continue,
+	}
+
+	for _, cd := range d.When {
+		condition, err := cd.toCondition()
+		if err != nil {
+			return nil, err
+		}
+		rule.Conditions = append(rule.Conditions, *condition)
+	}
+
+	return rule, nil
+}
+
+// toCondition converts a definition into a domain condition
+func (d *conditionDefinition) toCondition() (*entity.Condition, error) {
+	condition := &entity.Condition{Field: d.Field}
+	set := 0
+
+	if d.Equals != "" {
+		condition.Op, condition.Value = entity.OpEquals, d.Equals
+		set++
+	}
+	if d.Contains != "" {
+		condition.Op, condition.Value = entity.OpContains, d.Contains
+		set++
+	}
+	if d.Matches != "" {
+		pattern, err := regexp.Compile(d.Matches)
+		if err != nil {
+			return nil, fmt.Errorf("condition on %s has an invalid pattern: %w", d.Field, err)
+		}
+		condition.Op, condition.Pattern = entity.OpMatches, pattern
+		set++
+	}
+	if len(d.In) > 0 {
+		condition.Op, condition.Values = entity.OpIn, d.In
+		set++
+	}
+
+	if set != 1 {
+		return nil, fmt.Errorf("%w (field %s)", errConditionOperator, d.Field)
+	}
+	return condition, nil
+}
@@ -0,0 +1,318 @@
+package spam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+)
+
+// Classifier tuning
+const (
+	minTokenLength = 3
+	maxTokenLength = 40
+
+	// unknownWeight is how many messages' worth of evidence the neutral 0.5
+	// counts for, so tokens seen once or twice can't decide a verdict
+	unknownWeight = 1.0
+
+	// interestingTokens is how many of the most telling tokens are combined
+	interestingTokens = 15
+
+	// tokenHashLength is how many hex characters of a token's keyed hash are kept
+	tokenHashLength = 32
+)
+
+// urlHostRegex captures the host of links, which are kept as whole tokens
+var urlHostRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)([a-z0-9.-]+)`)
+
+// tokenCounts is how many spam and ham messages contained a token
+type tokenCounts struct {
+	Spam int `json:"s,omitempty"`
+	Ham  int `json:"h,omitempty"`
+}
+
+// bayesFile is the persisted form of the classifier
+type bayesFile struct {
+	SpamMessages int                    `json:"spam_messages"`
+	HamMessages  int                    `json:"ham_messages"`
+	Hashed       bool                   `json:"hashed,omitempty"` // tokens are keyed hashes
+	Tokens       map[string]tokenCounts `json:"tokens"`
+}
+
+// bayesClassifier is a naive Bayes spam classifier over the tokens of the
+// subject and message, persisted as JSON. Whole messages are never kept, but
+// the tokens are the words of trained messages. With encryption keys they are
+// stored as keyed hashes (HMAC with the index key); without keys the file holds
+// that vocabulary in plain text, and erasure requests don't remove it.
+type bayesClassifier struct {
+	path string
+	keys *encryption.Keyring // nil stores tokens in plain text
+
+	mu   sync.RWMutex
+	data bayesFile
+}
+
+// NewBayesClassifier loads the classifier saved at path, or starts an
+// untrained one when the file doesn't exist yet. Tokens are hashed with keys
+// unless it is nil; a plain-text file is hashed and saved again on load.
+func NewBayesClassifier(path string, keys *encryption.Keyring) (repository.SpamClassifier, error) {
+	c := &bayesClassifier{
+		path: path,
+		keys: keys,
+		data: bayesFile{Tokens: make(map[string]tokenCounts)},
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		c.data.Hashed = keys != nil
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spam classifier: %w", err)
+	}
+	if err := json.Unmarshal(raw, &c.data); err != nil {
+		return nil, fmt.Errorf("failed to parse spam classifier %s: %w", path, err)
+	}
+	if c.data.Tokens == nil {
+		c.data.Tokens = make(map[string]tokenCounts)
+	}
+
+	switch {
+	case c.data.Hashed && keys == nil:
+		return nil, fmt.Errorf("spam classifier %s holds hashed tokens, which need the encryption keys it was trained with", path)
+	case !c.data.Hashed && keys != nil:
+		if err := c.hashTokens(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// hashTokens replaces the plain-text tokens of a classifier trained without
+// keys by their keyed hashes and saves it, so the words don't stay on disk
+func (c *bayesClassifier) hashTokens() error {
+	hashed := make(map[string]tokenCounts, len(c.data.Tokens))
+	for t, counts := range c.data.Tokens {
+		h := c.hash(t)
+		merged := hashed[h]
+		merged.Spam += counts.Spam
+		merged.Ham += counts.Ham
+		hashed[h] = merged
+	}
+	c.data.Tokens = hashed
+	c.data.Hashed = true
+
+	if err := c.Save(); err != nil {
+		return err
+	}
+	log.Printf("[BayesClassifier] Replaced %d plain-text tokens with keyed hashes", len(hashed))
+	return nil
+}
+
+// Learn counts the contact's tokens as spam or ham
+func (c *bayesClassifier) Learn(contact *entity.Contact, spam bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.tokens(contact) {
+		counts := c.data.Tokens[t]
+		if spam {
+			counts.Spam++
+		} else {
+			counts.Ham++
+		}
+		c.data.Tokens[t] = counts
+	}
+	if spam {
+		c.data.SpamMessages++
+	} else {
+		c.data.HamMessages++
+	}
+}
+
+// Unlearn removes the contact's tokens from the spam or ham counts
+func (c *bayesClassifier) Unlearn(contact *entity.Contact, spam bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, t := range c.tokens(contact) {
+		counts, ok := c.data.Tokens[t]
+		if !ok {
+			continue
+		}
+		if spam && counts.Spam > 0 {
+			counts.Spam--
+		} else if !spam && counts.Ham > 0 {
+			counts.Ham--
+		}
+
+		if counts.Spam == 0 && counts.Ham == 0 {
+			delete(c.data.Tokens, t)
+		} else {
+			c.data.Tokens[t] = counts
+		}
+	}
+	if spam && c.data.SpamMessages > 0 {
+		c.data.SpamMessages--
+	} else if !spam && c.data.HamMessages > 0 {
+		c.data.HamMessages--
+	}
+}
+
+// SpamProbability combines the spam probabilities of the contact's most
+// telling tokens. Each token's probability is weighted towards 0.5 by how
+// rarely it was seen (Robinson), so one-off words carry little weight.
+func (c *bayesClassifier) SpamProbability(contact *entity.Contact) float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.data.SpamMessages == 0 || c.data.HamMessages == 0 {
+		return 0.5
+	}
+
+	var probabilities []float64
+	for _, t := range c.tokens(contact) {
+		counts, ok := c.data.Tokens[t]
+		if !ok {
+			continue
+		}
+
+		// Compare frequencies rather than counts, so an unbalanced corpus
+		// doesn't make every token look like the larger class
+		spamFreq := float64(counts.Spam) / float64(c.data.SpamMessages)
+		hamFreq := float64(counts.Ham) / float64(c.data.HamMessages)
+		p := spamFreq / (spamFreq + hamFreq)
+
+		n := float64(counts.Spam + counts.Ham)
+		probabilities = append(probabilities, (unknownWeight*0.5+n*p)/(unknownWeight+n))
+	}
+	if len(probabilities) == 0 {
+		return 0.5
+	}
+
+	sort.Slice(probabilities, func(i, j int) bool {
+		return math.Abs(probabilities[i]-0.5) > math.Abs(probabilities[j]-0.5)
+	})
+	if len(probabilities) > interestingTokens {
+		probabilities = probabilities[:interestingTokens]
+	}
+
+	// Multiply in log space to avoid underflow
+	var logSpam, logHam float64
+	for _, p := range probabilities {
+		logSpam += math.Log(p)
+		logHam += math.Log(1 - p)
+	}
+	return 1 / (1 + math.Exp(logHam-logSpam))
+}
+
+// Stats returns the number of messages and tokens learned
+func (c *bayesClassifier) Stats() entity.ClassifierStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return entity.ClassifierStats{
+		SpamMessages: c.data.SpamMessages,
+		HamMessages:  c.data.HamMessages,
+		Tokens:       len(c.data.Tokens),
+	}
+}
+
+// Save writes the classifier to its file. It writes a temporary file first
+// so a crash never leaves a truncated classifier behind.
+func (c *bayesClassifier) Save() error {
+	c.mu.RLock()
+	raw, err := json.Marshal(&c.data)
+	c.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode spam classifier: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create spam classifier directory: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write spam classifier: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write spam classifier: %w", err)
+	}
+	return nil
+}
+
+// tokens returns the keys the contact's tokens are counted under: the tokens
+// themselves, or their keyed hashes when the classifier has keys
+func (c *bayesClassifier) tokens(contact *entity.Contact) []string {
+	tokens := tokenize(contact)
+	if c.keys != nil {
+		for i, t := range tokens {
+			tokens[i] = c.hash(t)
+		}
+	}
+	return tokens
+}
+
+// hash returns the keyed hash of a token. The prefix keeps token hashes apart
+// from the email blind indexes made with the same key.
+func (c *bayesClassifier) hash(token string) string {
+	return c.keys.BlindIndex("bayes:" + token)[:tokenHashLength]
+}
+
+// tokenize returns the distinct tokens of a contact: lower-cased words of the
+// message, words of the subject marked as such, and link hosts
+func tokenize(contact *entity.Contact) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(t string) {
+		if !seen[t] {
+			seen[t] = true
+			tokens = append(tokens, t)
+		}
+	}
+
+	words := func(text, prefix string) {
+		for _, w := range strings.FieldsFunc(strings.ToLower(text), isTokenSeparator) {
+			w = strings.Trim(w, "'-")
+			if n := utf8.RuneCountInString(w); n >= minTokenLength && n <= maxTokenLength {
+				add(prefix + w)
+			}
+		}
+	}
+	words(contact.Subject, "subject:")
+	words(contact.Message, "")
+
+	for _, m := range urlHostRegex.FindAllStringSubmatch(contact.Message, -1) {
+		host := strings.TrimSuffix(strings.ToLower(m[1]), ".")
+		add("url:" + strings.TrimPrefix(host, "www."))
+	}
+
+	return tokens
+}
+
+// isTokenSeparator reports whether r splits words. Apostrophes, currency signs
+// and dashes stay inside tokens because "don't", "$500" and "e-mail" are telling.
+func isTokenSeparator(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return false
+	}
+	switch r {
+	case '\'', '-', '$', '€', '£':
+		return false
+	}
+	return true
+}
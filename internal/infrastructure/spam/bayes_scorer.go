@@ -0,0 +1,46 @@
+package spam
+
+import (
+	"fmt"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// bayesScorer gives points to contacts the trained classifier considers spam
+type bayesScorer struct {
+	classifier  repository.SpamClassifier
+	points      float64
+	probability float64 // spam probability from which points are given
+	minTrained  int     // spam and ham messages each needed before scoring
+}
+
+// NewBayesScorer creates a scorer backed by a spam classifier. It stays
+// silent until the classifier has learned minTrained spam and ham messages.
+func NewBayesScorer(classifier repository.SpamClassifier, points, probability float64, minTrained int) repository.SpamScorer {
+	return &bayesScorer{
+		classifier:  classifier,
+		points:      points,
+		probability: probability,
+		minTrained:  minTrained,
+	}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *bayesScorer) Name() string {
+	return "bayes"
+}
+
+// Score gives points when the spam probability reaches the threshold
+func (s *bayesScorer) Score(contact *entity.Contact) (float64, string) {
+	stats := s.classifier.Stats()
+	if stats.SpamMessages < s.minTrained || stats.HamMessages < s.minTrained {
+		return 0, ""
+	}
+
+	p := s.classifier.SpamProbability(contact)
+	if p < s.probability {
+		return 0, ""
+	}
+	return s.points, fmt.Sprintf("spam probability %.2f", p)
+}
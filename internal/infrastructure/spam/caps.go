@@ -0,0 +1,48 @@
+package spam
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// capsScorer gives points to messages written mostly in capitals
+type capsScorer struct {
+	points     float64
+	ratio      float64 // share of capital letters from which points are given
+	minLetters int     // shorter messages are ignored
+}
+
+// NewCapsScorer creates a scorer for the share of capital letters in the message
+func NewCapsScorer(points, ratio float64, minLetters int) repository.SpamScorer {
+	return &capsScorer{points: points, ratio: ratio, minLetters: minLetters}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *capsScorer) Name() string {
+	return "caps"
+}
+
+// Score gives points when the share of capitals reaches the ratio
+func (s *capsScorer) Score(contact *entity.Contact) (float64, string) {
+	letters, upper := 0, 0
+	for _, r := range contact.Message {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+
+	if letters == 0 || letters < s.minLetters {
+		return 0, ""
+	}
+	share := float64(upper) / float64(letters)
+	if share < s.ratio {
+		return 0, ""
+	}
+	return s.points, fmt.Sprintf("%.0f%% capitals", share*100)
+}
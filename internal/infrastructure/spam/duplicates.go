@@ -0,0 +1,96 @@
+package spam
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// duplicateScorer gives points to a message already received recently.
+// Messages are only kept as hashes of their normalised text.
+type duplicateScorer struct {
+	points float64
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[[sha256.Size]byte][]time.Time
+}
+
+// NewDuplicateScorer creates a scorer for messages repeated within window
+func NewDuplicateScorer(points float64, window time.Duration) repository.SpamScorer {
+	return &duplicateScorer{
+		points: points,
+		window: window,
+		seen:   make(map[[sha256.Size]byte][]time.Time),
+	}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *duplicateScorer) Name() string {
+	return "duplicates"
+}
+
+// Score gives points if the message was accepted before within the window
+func (s *duplicateScorer) Score(contact *entity.Contact) (float64, string) {
+	key, ok := messageKey(contact)
+	if !ok {
+		return 0, ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(time.Now())
+	previous := len(s.seen[key])
+	if previous == 0 {
+		return 0, ""
+	}
+	return s.points, fmt.Sprintf("%d earlier copies within %v", previous, s.window)
+}
+
+// Record remembers an accepted message
+func (s *duplicateScorer) Record(contact *entity.Contact) {
+	key, ok := messageKey(contact)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.prune(now)
+	s.seen[key] = append(s.seen[key], now)
+}
+
+// prune forgets messages outside the window
+func (s *duplicateScorer) prune(now time.Time) {
+	for k, times := range s.seen {
+		recent := times[:0]
+		for _, at := range times {
+			if now.Sub(at) < s.window {
+				recent = append(recent, at)
+			}
+		}
+		if len(recent) == 0 {
+			delete(s.seen, k)
+		} else {
+			s.seen[k] = recent
+		}
+	}
+}
+
+// messageKey returns the hash a message is remembered by, ignoring case and
+// spacing so trivial variations still match. Empty messages have none.
+func messageKey(contact *entity.Contact) ([sha256.Size]byte, bool) {
+	normalised := strings.Join(strings.Fields(strings.ToLower(contact.Message)), " ")
+	if normalised == "" {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256([]byte(normalised)), true
+}
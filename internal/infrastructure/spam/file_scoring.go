@@ -0,0 +1,200 @@
+package spam
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scoring definition errors
+var (
+	errScriptLocale = errors.New("script scorer needs the site locale")
+	errNoTLDs       = errors.New("tld scorer needs at least one top-level domain")
+	errNoKeywords   = errors.New("keyword scorer needs at least one word or pattern")
+	errNoClassifier = errors.New("bayes scorer needs a spam classifier")
+)
+
+// fileDefinition is the top level of a spam scoring file. Each scorer is
+// enabled by declaring its section.
+type fileDefinition struct {
+	Thresholds thresholdsDefinition `json:"thresholds" yaml:"thresholds"`
+	Links      *linksDefinition     `json:"links" yaml:"links"`
+	Keywords   *keywordsDefinition  `json:"keywords" yaml:"keywords"`
+	Caps       *capsDefinition      `json:"caps" yaml:"caps"`
+	Script     *scriptDefinition    `json:"script" yaml:"script"`
+	Duplicates *duplicateDefinition `json:"duplicates" yaml:"duplicates"`
+	TLDs       *tldsDefinition      `json:"tlds" yaml:"tlds"`
+	Bayes      *bayesDefinition     `json:"bayes" yaml:"bayes"`
+}
+
+// thresholdsDefinition holds the scores at which each action applies
+type thresholdsDefinition struct {
+	Tag        float64 `json:"tag" yaml:"tag"`
+	Quarantine float64 `json:"quarantine" yaml:"quarantine"`
+	Reject     float64 `json:"reject" yaml:"reject"`
+}
+
+// linksDefinition configures the link scorer
+type linksDefinition struct {
+	Points *float64 `json:"points" yaml:"points"` // per link; default 1
+	Free   *int     `json:"free" yaml:"free"`     // default 1
+}
+
+// keywordsDefinition configures the blocklist scorer
+type keywordsDefinition struct {
+	Points   *float64 `json:"points" yaml:"points"` // per match; default 3
+	Words    []string `json:"words" yaml:"words"`
+	Patterns []string `json:"patterns" yaml:"patterns"`
+}
+
+// capsDefinition configures the capitals scorer
+type capsDefinition struct {
+	Points     *float64 `json:"points" yaml:"points"`           // default 2
+	Ratio      *float64 `json:"ratio" yaml:"ratio"`             // default 0.7
+	MinLetters *int     `json:"min_letters" yaml:"min_letters"` // default 20
+}
+
+// scriptDefinition configures the script/locale scorer
+type scriptDefinition struct {
+	Points *float64 `json:"points" yaml:"points"` // default 3
+	Locale string   `json:"locale" yaml:"locale"`
+	Ratio  *float64 `json:"ratio" yaml:"ratio"` // default 0.3
+}
+
+// duplicateDefinition configures the duplicate message scorer
+type duplicateDefinition struct {
+	Points        *float64 `json:"points" yaml:"points"`                 // default 4
+	WindowMinutes *int     `json:"window_minutes" yaml:"window_minutes"` // default 60
+}
+
+// tldsDefinition configures the suspicious TLD scorer
+type tldsDefinition struct {
+	Points *float64 `json:"points" yaml:"points"` // default 2
+	List   []string `json:"list" yaml:"list"`
+}
+
+// bayesDefinition configures the trained classifier scorer
+type bayesDefinition struct {
+	Points      *float64 `json:"points" yaml:"points"`           // default 5
+	Probability *float64 `json:"probability" yaml:"probability"` // default 0.9
+	MinTrained  *int     `json:"min_trained" yaml:"min_trained"` // spam and ham messages each; default 20
+}
+
+// NewFileSpamScoring loads spam scorers and thresholds from a JSON or YAML
+// file. The bayes scorer rates contacts with classifier. An empty path
+// disables scoring.
+func NewFileSpamScoring(path string, classifier repository.SpamClassifier) ([]repository.SpamScorer, entity.SpamThresholds, error) {
+	if path == "" {
+		return nil, entity.SpamThresholds{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, entity.SpamThresholds{}, fmt.Errorf("failed to read spam scoring: %w", err)
+	}
+
+	var def fileDefinition
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &def)
+	default:
+		err = json.Unmarshal(data, &def)
+	}
+	if err != nil {
+		return nil, entity.SpamThresholds{}, fmt.Errorf("failed to parse spam scoring %s: %w", path, err)
+	}
+
+	scorers, err := def.toScorers(classifier)
+	if err != nil {
+		return nil, entity.SpamThresholds{}, fmt.Errorf("invalid spam scoring %s: %w", path, err)
+	}
+
+	thresholds := entity.SpamThresholds{
+		Tag:        def.Thresholds.Tag,
+		Quarantine: def.Thresholds.Quarantine,
+		Reject:     def.Thresholds.Reject,
+	}
+	return scorers, thresholds, nil
+}
+
+// toScorers builds the scorers whose sections are declared
+func (d *fileDefinition) toScorers(classifier repository.SpamClassifier) ([]repository.SpamScorer, error) {
+	var scorers []repository.SpamScorer
+
+	if l := d.Links; l != nil {
+		scorers = append(scorers, NewLinkScorer(orFloat(l.Points, 1), orInt(l.Free, 1)))
+	}
+
+	if k := d.Keywords; k != nil {
+		if len(k.Words) == 0 && len(k.Patterns) == 0 {
+			return nil, errNoKeywords
+		}
+		patterns := make([]*regexp.Regexp, 0, len(k.Patterns))
+		for _, p := range k.Patterns {
+			pattern, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid keyword pattern: %w", err)
+			}
+			patterns = append(patterns, pattern)
+		}
+		scorers = append(scorers, NewKeywordScorer(orFloat(k.Points, 3), k.Words, patterns))
+	}
+
+	if c := d.Caps; c != nil {
+		scorers = append(scorers, NewCapsScorer(orFloat(c.Points, 2), orFloat(c.Ratio, 0.7), orInt(c.MinLetters, 20)))
+	}
+
+	if s := d.Script; s != nil {
+		if s.Locale == "" {
+			return nil, errScriptLocale
+		}
+		scorers = append(scorers, NewScriptScorer(orFloat(s.Points, 3), s.Locale, orFloat(s.Ratio, 0.3)))
+	}
+
+	if dup := d.Duplicates; dup != nil {
+		window := time.Duration(orInt(dup.WindowMinutes, 60)) * time.Minute
+		scorers = append(scorers, NewDuplicateScorer(orFloat(dup.Points, 4), window))
+	}
+
+	if t := d.TLDs; t != nil {
+		if len(t.List) == 0 {
+			return nil, errNoTLDs
+		}
+		scorers = append(scorers, NewTLDScorer(orFloat(t.Points, 2), t.List))
+	}
+
+	if b := d.Bayes; b != nil {
+		if classifier == nil {
+			return nil, errNoClassifier
+		}
+		scorers = append(scorers, NewBayesScorer(classifier, orFloat(b.Points, 5), orFloat(b.Probability, 0.9), orInt(b.MinTrained, 20)))
+	}
+
+	return scorers, nil
+}
+
+// orFloat returns *v, or def when v is unset
+func orFloat(v *float64, def float64) float64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+// orInt returns *v, or def when v is unset
+func orInt(v *int, def int) int {
+	if v == nil {
+		return def
+	}
+	return *v
+}
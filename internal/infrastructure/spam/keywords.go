@@ -0,0 +1,60 @@
+package spam
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// keywordScorer gives points for every blocklisted keyword or pattern found
+// in the name, subject or message
+type keywordScorer struct {
+	points   float64 // per match
+	words    []string
+	patterns []*regexp.Regexp
+}
+
+// NewKeywordScorer creates a scorer for blocklisted keywords, matched
+// case-insensitively, and regular expressions, matched as written
+func NewKeywordScorer(points float64, words []string, patterns []*regexp.Regexp) repository.SpamScorer {
+	lower := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			lower = append(lower, w)
+		}
+	}
+
+	return &keywordScorer{points: points, words: lower, patterns: patterns}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *keywordScorer) Name() string {
+	return "keywords"
+}
+
+// Score gives points for each keyword and pattern that matches
+func (s *keywordScorer) Score(contact *entity.Contact) (float64, string) {
+	text := strings.Join([]string{contact.Name, contact.Subject, contact.Message}, "\n")
+	lower := strings.ToLower(text)
+
+	// Report what matched from the blocklist, not from the submission
+	var matched []string
+	for _, w := range s.words {
+		if strings.Contains(lower, w) {
+			matched = append(matched, fmt.Sprintf("%q", w))
+		}
+	}
+	for _, p := range s.patterns {
+		if p.MatchString(text) {
+			matched = append(matched, "/"+p.String()+"/")
+		}
+	}
+
+	if len(matched) == 0 {
+		return 0, ""
+	}
+	return float64(len(matched)) * s.points, "matched " + strings.Join(matched, ", ")
+}
@@ -0,0 +1,37 @@
+package spam
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// linkRegex matches URLs and bare www. links
+var linkRegex = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// linkScorer gives points for every link in the message beyond the free ones
+type linkScorer struct {
+	points float64 // per link
+	free   int     // links allowed without points
+}
+
+// NewLinkScorer creates a scorer counting links in the message
+func NewLinkScorer(points float64, free int) repository.SpamScorer {
+	return &linkScorer{points: points, free: free}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *linkScorer) Name() string {
+	return "links"
+}
+
+// Score gives points for each link beyond the free ones
+func (s *linkScorer) Score(contact *entity.Contact) (float64, string) {
+	n := len(linkRegex.FindAllString(contact.Message, -1))
+	if n <= s.free {
+		return 0, ""
+	}
+	return float64(n-s.free) * s.points, fmt.Sprintf("%d links", n)
+}
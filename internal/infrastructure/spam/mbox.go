@@ -0,0 +1,178 @@
+package spam
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// Patterns used to reduce HTML parts to text
+var (
+	htmlHiddenRegex = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)>`)
+	htmlTagRegex    = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// ReadMbox calls fn with the subject and text of every message in an mbox
+// file, in order. Messages that can't be parsed are skipped and counted; an
+// error from fn stops reading.
+func ReadMbox(r io.Reader, fn func(*entity.Contact) error) (skipped int, err error) {
+	reader := bufio.NewReader(r)
+	var current bytes.Buffer
+	started := false
+	previousBlank := true
+
+	flush := func() error {
+		if !started {
+			return nil
+		}
+		contact, err := parseMboxMessage(current.Bytes())
+		current.Reset()
+		if err != nil {
+			skipped++
+			return nil
+		}
+		return fn(contact)
+	}
+
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return skipped, fmt.Errorf("failed to read mbox: %w", readErr)
+		}
+
+		if line != "" {
+			// A "From " line after a blank line starts the next message
+			if previousBlank && strings.HasPrefix(line, "From ") {
+				if err := flush(); err != nil {
+					return skipped, err
+				}
+				started = true
+			} else if started {
+				current.WriteString(unescapeFromLine(line))
+			}
+			previousBlank = strings.TrimRight(line, "\r\n") == ""
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	if err := flush(); err != nil {
+		return skipped, err
+	}
+	return skipped, nil
+}
+
+// unescapeFromLine removes the ">" mbox writers put before body lines starting with "From "
+func unescapeFromLine(line string) string {
+	if strings.HasPrefix(line, ">") && strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+		return line[1:]
+	}
+	return line
+}
+
+// parseMboxMessage returns a contact holding a message's decoded subject and text
+func parseMboxMessage(raw []byte) (*entity.Contact, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	plain, htmlText := partText(msg.Header, msg.Body)
+	text := plain
+	if strings.TrimSpace(text) == "" {
+		text = htmlText
+	}
+
+	return &entity.Contact{
+		Subject: strings.TrimSpace(subject),
+		Message: strings.TrimSpace(text),
+	}, nil
+}
+
+// partText returns the plain-text and HTML content of a message part,
+// descending into multipart parts. Unreadable parts are left out.
+func partText(header map[string][]string, body io.Reader) (plain, htmlText string) {
+	get := func(key string) string {
+		if v := header[key]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	mediaType, params, err := mime.ParseMediaType(get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		var plains, htmls []string
+		for {
+			part, err := mr.NextRawPart()
+			if err != nil {
+				break
+			}
+			p, h := partText(part.Header, part)
+			plains = append(plains, p)
+			htmls = append(htmls, h)
+		}
+		return strings.Join(plains, "\n"), strings.Join(htmls, "\n")
+	}
+
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", ""
+	}
+
+	switch strings.ToLower(strings.TrimSpace(get("Content-Transfer-Encoding"))) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", ""
+	}
+	text := decodeCharset(data)
+
+	if mediaType == "text/html" {
+		text = htmlHiddenRegex.ReplaceAllString(text, " ")
+		text = html.UnescapeString(htmlTagRegex.ReplaceAllString(text, " "))
+		return "", text
+	}
+	return text, ""
+}
+
+// decodeCharset returns data as a string, reading it as Latin-1 when it isn't
+// valid UTF-8. That covers most older mail well enough to tokenise.
+func decodeCharset(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
@@ -0,0 +1,93 @@
+package spam
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// script is a writing system checked against the site locale
+type script struct {
+	name    string
+	tables  []*unicode.RangeTable
+	locales []string // languages normally written in it
+}
+
+// checkedScripts are the scripts that count against a site in another language
+var checkedScripts = []script{
+	{
+		name:    "cyrillic",
+		tables:  []*unicode.RangeTable{unicode.Cyrillic},
+		locales: []string{"ru", "uk", "be", "bg", "sr", "mk", "kk", "ky", "mn", "tg"},
+	},
+	{
+		name:    "cjk",
+		tables:  []*unicode.RangeTable{unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul},
+		locales: []string{"zh", "ja", "ko"},
+	},
+}
+
+// scriptScorer gives points to text largely written in a script the site's
+// locale doesn't use, e.g. Cyrillic or CJK sent to an English site
+type scriptScorer struct {
+	points float64
+	locale string  // language of the site, e.g. "en" or "en-GB"
+	ratio  float64 // share of letters in a foreign script from which points are given
+}
+
+// NewScriptScorer creates a scorer for scripts that don't match the site locale
+func NewScriptScorer(points float64, locale string, ratio float64) repository.SpamScorer {
+	return &scriptScorer{points: points, locale: locale, ratio: ratio}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *scriptScorer) Name() string {
+	return "script"
+}
+
+// Score gives points when a foreign script makes up enough of the subject and message
+func (s *scriptScorer) Score(contact *entity.Contact) (float64, string) {
+	language := strings.ToLower(s.locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+
+	text := contact.Subject + "\n" + contact.Message
+	letters := 0
+	counts := make([]int, len(checkedScripts))
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for i, sc := range checkedScripts {
+			if unicode.In(r, sc.tables...) {
+				counts[i]++
+			}
+		}
+	}
+	if letters == 0 {
+		return 0, ""
+	}
+
+	for i, sc := range checkedScripts {
+		share := float64(counts[i]) / float64(letters)
+		if share >= s.ratio && !contains(sc.locales, language) {
+			return s.points, fmt.Sprintf("%.0f%% %s for locale %s", share*100, sc.name, s.locale)
+		}
+	}
+	return 0, ""
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
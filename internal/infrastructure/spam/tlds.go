@@ -0,0 +1,45 @@
+package spam
+
+import (
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// tldScorer gives points to submitter addresses under a suspicious top-level domain
+type tldScorer struct {
+	points float64
+	tlds   map[string]bool
+}
+
+// NewTLDScorer creates a scorer for the top-level domain of the submitter's email
+func NewTLDScorer(points float64, tlds []string) repository.SpamScorer {
+	set := make(map[string]bool, len(tlds))
+	for _, tld := range tlds {
+		if tld = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tld), ".")); tld != "" {
+			set[tld] = true
+		}
+	}
+
+	return &tldScorer{points: points, tlds: set}
+}
+
+// Name identifies the scorer in score breakdowns
+func (s *tldScorer) Name() string {
+	return "tlds"
+}
+
+// Score gives points when the email's top-level domain is listed
+func (s *tldScorer) Score(contact *entity.Contact) (float64, string) {
+	i := strings.LastIndex(contact.Email, ".")
+	if i < 0 || !strings.Contains(contact.Email, "@") {
+		return 0, ""
+	}
+
+	tld := strings.ToLower(contact.Email[i+1:])
+	if !s.tlds[tld] {
+		return 0, ""
+	}
+	return s.points, "." + tld
+}
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// ContactRecord is the persisted form of a contact, shared by the bbolt repositories
+type ContactRecord struct {
+	ID          string        `json:"id"`
+	FormID      string        `json:"form_id,omitempty"`
+	Name        string        `json:"name"`
+	Email       string        `json:"email"`
+	Subject     string        `json:"subject"`
+	Message     string        `json:"message"`
+	Fields      []FieldRecord `json:"fields,omitempty"`
+	To          []string      `json:"to,omitempty"`
+	CC          []string      `json:"cc,omitempty"`
+	BCC         []string      `json:"bcc,omitempty"`
+	Route       string        `json:"route,omitempty"`
+	SubmittedAt time.Time     `json:"submitted_at"`
+	Spam        *SpamRecord   `json:"spam,omitempty"`
+	KeyID       string        `json:"key_id,omitempty"`     // key the personal fields are encrypted with
+	EmailHash   string        `json:"email_hash,omitempty"` // blind index of Email while encrypted
+}
+
+// FieldRecord is the persisted form of a submitted form field
+type FieldRecord struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// SpamRecord is the persisted form of a contact's spam score
+type SpamRecord struct {
+	Total  float64          `json:"total"`
+	Action string           `json:"action,omitempty"`
+	Rules  []SpamRuleRecord `json:"rules,omitempty"`
+}
+
+// SpamRuleRecord is the persisted form of one rule's contribution to a spam score
+type SpamRuleRecord struct {
+	Rule   string  `json:"rule"`
+	Points float64 `json:"points"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+// NewContactRecord converts a contact into its persisted form
+func NewContactRecord(contact *entity.Contact) ContactRecord {
+	rec := ContactRecord{
+		ID:          contact.ID,
+		FormID:      contact.FormID,
+		Name:        contact.Name,
+		Email:       contact.Email,
+		Subject:     contact.Subject,
+		Message:     contact.Message,
+		To:          contact.Recipients.To,
+		CC:          contact.Recipients.CC,
+		BCC:         contact.Recipients.BCC,
+		Route:       contact.Route,
+		SubmittedAt: contact.SubmittedAt,
+	}
+	for _, f := range contact.Fields {
+		rec.Fields = append(rec.Fields, FieldRecord{Name: f.Name, Label: f.Label, Value: f.Value})
+	}
+	if contact.Spam != nil {
+		rec.Spam = &SpamRecord{Total: contact.Spam.Total, Action: string(contact.Spam.Action)}
+		for _, r := range contact.Spam.Rules {
+			rec.Spam.Rules = append(rec.Spam.Rules, SpamRuleRecord{Rule: r.Rule, Points: r.Points, Detail: r.Detail})
+		}
+	}
+	return rec
+}
+
+// ToContact converts a record back into a domain entity
+func (r *ContactRecord) ToContact() *entity.Contact {
+	contact := &entity.Contact{
+		ID:          r.ID,
+		FormID:      r.FormID,
+		Name:        r.Name,
+		Email:       r.Email,
+		Subject:     r.Subject,
+		Message:     r.Message,
+		Recipients:  entity.Recipients{To: r.To, CC: r.CC, BCC: r.BCC},
+		Route:       r.Route,
+		SubmittedAt: r.SubmittedAt,
+	}
+	for _, f := range r.Fields {
+		contact.Fields = append(contact.Fields, entity.FieldValue{Name: f.Name, Label: f.Label, Value: f.Value})
+	}
+	if r.Spam != nil {
+		contact.Spam = &entity.SpamScore{Total: r.Spam.Total, Action: entity.SpamAction(r.Spam.Action)}
+		for _, sr := range r.Spam.Rules {
+			contact.Spam.Rules = append(contact.Spam.Rules, entity.SpamRuleScore{Rule: sr.Rule, Points: sr.Points, Detail: sr.Detail})
+		}
+	}
+	return contact
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+)
+
+// ErrEncryptedRecord is returned when reading an encrypted record without keys
+var ErrEncryptedRecord = errors.New("record is encrypted but no encryption keys are configured")
+
+// Seal encrypts the personal fields of the record in place with the active
+// key, together with extra fields of the record embedding it (e.g. client IP).
+// It does nothing when keys is nil or the record is already sealed.
+func (r *ContactRecord) Seal(keys *encryption.Keyring, extra map[string]*string) error {
+	if keys == nil || r.KeyID != "" {
+		return nil
+	}
+
+	if r.Email != "" {
+		r.EmailHash = keys.BlindIndex(r.Email)
+	}
+	for name, value := range r.sealedFields(extra) {
+		if *value == "" {
+			continue
+		}
+		sealed, err := keys.Encrypt(*value, r.ID+"/"+name)
+		if err != nil {
+			return err
+		}
+		*value = sealed
+	}
+
+	r.KeyID = keys.ActiveKeyID()
+	return nil
+}
+
+// Open decrypts a record sealed by Seal in place. Plaintext records are left as they are.
+func (r *ContactRecord) Open(keys *encryption.Keyring, extra map[string]*string) error {
+	if r.KeyID == "" {
+		return nil
+	}
+	if keys == nil {
+		return fmt.Errorf("%w: %s", ErrEncryptedRecord, r.ID)
+	}
+
+	for name, value := range r.sealedFields(extra) {
+		if *value == "" {
+			continue
+		}
+		plain, err := keys.Decrypt(r.KeyID, *value, r.ID+"/"+name)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s of record %s: %w", name, r.ID, err)
+		}
+		*value = plain
+	}
+
+	r.KeyID = ""
+	r.EmailHash = ""
+	return nil
+}
+
+// HasEmail reports whether the record is from the given submitter address,
+// ignoring case and surrounding spaces like the blind index used when the
+// record is sealed
+func (r *ContactRecord) HasEmail(keys *encryption.Keyring, email string) bool {
+	if r.KeyID != "" {
+		return keys != nil && r.EmailHash == keys.BlindIndex(email)
+	}
+	return strings.EqualFold(strings.TrimSpace(r.Email), strings.TrimSpace(email))
+}
+
+// Copy returns a copy of the record that can be sealed without changing r
+func (r *ContactRecord) Copy() ContactRecord {
+	c := *r
+	c.Fields = append([]FieldRecord(nil), r.Fields...)
+	return c
+}
+
+// sealedFields returns the fields holding personal data, keyed by a name that
+// is bound to the ciphertext so values can't be swapped between fields
+func (r *ContactRecord) sealedFields(extra map[string]*string) map[string]*string {
+	fields := map[string]*string{
+		"name":    &r.Name,
+		"email":   &r.Email,
+		"subject": &r.Subject,
+		"message": &r.Message,
+	}
+	for i := range r.Fields {
+		fields["field:"+r.Fields[i].Name] = &r.Fields[i].Value
+	}
+	for name, value := range extra {
+		fields[name] = value
+	}
+	return fields
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+)
+
+// testKey returns a keyring entry whose key is fill repeated to the key size
+func testKey(id string, fill byte) string {
+	return id + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(fill), 32)))
+}
+
+// testKeyring builds a keyring from entries, failing the test on error
+func testKeyring(t *testing.T, activeID string, entries ...string) *encryption.Keyring {
+	t.Helper()
+	keys, err := encryption.NewKeyring(strings.Join(entries, ","), "", activeID)
+	if err != nil {
+		t.Fatalf("failed to build keyring: %v", err)
+	}
+	return keys
+}
+
+// testRecord returns a record with every sealed field set
+func testRecord() ContactRecord {
+	return ContactRecord{
+		ID:      "018f3c2e-7b1a-7c3d-9f4e-2a1b3c4d5e6f",
+		Name:    "John Doe",
+		Email:   "John.Doe@example.com",
+		Subject: "Order #42",
+		Message: "Where is my order?",
+		Fields:  []FieldRecord{{Name: "phone", Label: "Phone", Value: "+44 20 7946 0958"}},
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	index := testKey(encryption.IndexKeyID, 'i')
+	oldKeys := testKeyring(t, "", index, testKey("2023", 'a'))
+	rotatedKeys := testKeyring(t, "2024", index, testKey("2023", 'a'), testKey("2024", 'b'))
+
+	tests := []struct {
+		name     string
+		sealWith *encryption.Keyring
+		openWith *encryption.Keyring
+		tamper   func(r *ContactRecord, clientIP *string)
+		wantErr  error
+	}{
+		{
+			name:     "round trip",
+			sealWith: oldKeys,
+			openWith: oldKeys,
+		},
+		{
+			name:     "sealed under an old key opens after rotation",
+			sealWith: oldKeys,
+			openWith: rotatedKeys,
+		},
+		{
+			name:     "sealed under the new key",
+			sealWith: rotatedKeys,
+			openWith: rotatedKeys,
+		},
+		{
+			name:     "ciphertext moved to another field",
+			sealWith: oldKeys,
+			openWith: oldKeys,
+			tamper: func(r *ContactRecord, clientIP *string) {
+				r.Subject = r.Message
+			},
+			wantErr: encryption.ErrInvalidSealed,
+		},
+		{
+			name:     "ciphertext moved to an extra field",
+			sealWith: oldKeys,
+			openWith: oldKeys,
+			tamper: func(r *ContactRecord, clientIP *string) {
+				*clientIP = r.Email
+			},
+			wantErr: encryption.ErrInvalidSealed,
+		},
+		{
+			name:     "ciphertext moved to another record",
+			sealWith: oldKeys,
+			openWith: oldKeys,
+			tamper: func(r *ContactRecord, clientIP *string) {
+				r.ID = "018f3c2e-7b1a-7c3d-9f4e-000000000000"
+			},
+			wantErr: encryption.ErrInvalidSealed,
+		},
+		{
+			name:     "sealed record without keys",
+			sealWith: oldKeys,
+			openWith: nil,
+			wantErr:  ErrEncryptedRecord,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := testRecord()
+			wantIP := "203.0.113.7"
+
+			r := testRecord()
+			clientIP := wantIP
+			if err := r.Seal(tt.sealWith, map[string]*string{"client_ip": &clientIP}); err != nil {
+				t.Fatalf("Seal() error = %v", err)
+			}
+			if r.KeyID != tt.sealWith.ActiveKeyID() {
+				t.Errorf("KeyID = %q, want %q", r.KeyID, tt.sealWith.ActiveKeyID())
+			}
+			if r.Name == want.Name || r.Email == want.Email || r.Fields[0].Value == want.Fields[0].Value || clientIP == wantIP {
+				t.Fatalf("Seal() left personal data in plain text: %+v", r)
+			}
+
+			if tt.tamper != nil {
+				tt.tamper(&r, &clientIP)
+			}
+
+			err := r.Open(tt.openWith, map[string]*string{"client_ip": &clientIP})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Open() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+
+			if r.Name != want.Name || r.Email != want.Email || r.Subject != want.Subject ||
+				r.Message != want.Message || r.Fields[0].Value != want.Fields[0].Value {
+				t.Errorf("Open() = %+v, want %+v", r, want)
+			}
+			if clientIP != wantIP {
+				t.Errorf("client_ip = %q, want %q", clientIP, wantIP)
+			}
+			if r.KeyID != "" || r.EmailHash != "" {
+				t.Errorf("Open() kept KeyID %q and EmailHash %q", r.KeyID, r.EmailHash)
+			}
+		})
+	}
+}
+
+func TestHasEmail(t *testing.T) {
+	keys := testKeyring(t, "", testKey(encryption.IndexKeyID, 'i'), testKey("2023", 'a'))
+
+	sealed := testRecord()
+	if err := sealed.Seal(keys, nil); err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	plain := testRecord()
+	spaced := testRecord()
+	spaced.Email = " John.Doe@example.com\n"
+
+	tests := []struct {
+		name   string
+		record ContactRecord
+		keys   *encryption.Keyring
+		email  string
+		want   bool
+	}{
+		{name: "sealed, same case", record: sealed, keys: keys, email: "John.Doe@example.com", want: true},
+		{name: "sealed, other case", record: sealed, keys: keys, email: "john.doe@EXAMPLE.COM", want: true},
+		{name: "sealed, surrounding spaces", record: sealed, keys: keys, email: " john.doe@example.com ", want: true},
+		{name: "sealed, other address", record: sealed, keys: keys, email: "jane@example.com", want: false},
+		{name: "sealed, no keys", record: sealed, keys: nil, email: "john.doe@example.com", want: false},
+		{name: "plain, other case", record: plain, keys: nil, email: "JOHN.DOE@example.com", want: true},
+		{name: "plain, surrounding spaces", record: plain, keys: nil, email: " john.doe@example.com ", want: true},
+		{name: "plain, stored with spaces", record: spaced, keys: nil, email: "john.doe@example.com", want: true},
+		{name: "plain, other address", record: plain, keys: keys, email: "jane@example.com", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.HasEmail(tt.keys, tt.email); got != tt.want {
+				t.Errorf("HasEmail(%q) = %v, want %v", tt.email, got, tt.want)
+			}
+		})
+	}
+}
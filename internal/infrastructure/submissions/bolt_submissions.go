@@ -0,0 +1,530 @@
+package submissions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/encryption"
+	"github.com/andrianprasetya/go-mail-server/internal/infrastructure/storage"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucketName is the bucket holding submissions keyed by ID. IDs are
+// time-ordered, so key order is submission order.
+var bucketName = []byte("submissions")
+
+// record is the persisted form of a submission
+type record struct {
+	storage.ContactRecord
+	ClientIP   string          `json:"client_ip,omitempty"`
+	UserAgent  string          `json:"user_agent,omitempty"`
+	Origin     string          `json:"origin,omitempty"`
+	Status     string          `json:"status"`
+	Provider   string          `json:"provider,omitempty"`
+	MessageID  string          `json:"message_id,omitempty"`
+	Attempts   int             `json:"attempts,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	History    []attemptRecord `json:"history,omitempty"`
+	Label      string          `json:"label,omitempty"`
+	Anonymised bool            `json:"anonymised,omitempty"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// attemptRecord is the persisted form of a delivery attempt
+type attemptRecord struct {
+	At        time.Time `json:"at"`
+	Resend    bool      `json:"resend,omitempty"`
+	To        []string  `json:"to,omitempty"`
+	CC        []string  `json:"cc,omitempty"`
+	BCC       []string  `json:"bcc,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	MessageID string    `json:"message_id,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// boltSubmissions implements SubmissionRepository using an embedded bbolt
+// database. Personal fields are encrypted with keys unless it is nil.
+type boltSubmissions struct {
+	db   *bolt.DB
+	keys *encryption.Keyring
+}
+
+// NewBoltSubmissions creates a new submission repository backed by db
+func NewBoltSubmissions(db *bolt.DB, keys *encryption.Keyring) (repository.SubmissionRepository, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create submissions bucket: %w", err)
+	}
+
+	return &boltSubmissions{db: db, keys: keys}, nil
+}
+
+// Create stores a newly accepted submission
+func (s *boltSubmissions) Create(submission *entity.Submission) error {
+	rec := newRecord(submission)
+	rec.UpdatedAt = time.Now().UTC()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx.Bucket(bucketName), s.keys, rec)
+	})
+}
+
+// Get returns a submission by ID
+func (s *boltSubmissions) Get(id string) (*entity.Submission, error) {
+	var submission *entity.Submission
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		rec, err := get(tx.Bucket(bucketName), s.keys, id)
+		if err != nil {
+			return err
+		}
+		submission = rec.toSubmission()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return submission, nil
+}
+
+// List returns the submissions matching filter, newest first
+func (s *boltSubmissions) List(filter entity.SubmissionFilter, offset, limit int) ([]*entity.Submission, int, error) {
+	var page []*entity.Submission
+	total := 0
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			submission, err := s.match(filter, v)
+			if err != nil {
+				return fmt.Errorf("failed to decode submission %s: %w", k, err)
+			}
+			if submission == nil {
+				continue
+			}
+
+			if total >= offset && len(page) < limit {
+				page = append(page, submission)
+			}
+			total++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return page, total, nil
+}
+
+// eachBatchSize is how many records Each reads per read transaction
+const eachBatchSize = 500
+
+// Each calls fn for every submission matching filter, oldest first. Records
+// are read in batches, each in its own short read transaction, so a slow fn
+// doesn't keep one transaction open and stop the database file reclaiming space.
+func (s *boltSubmissions) Each(filter entity.SubmissionFilter, fn func(*entity.Submission) error) error {
+	var after []byte
+
+	for {
+		var batch []*entity.Submission
+		done := true
+
+		err := s.db.View(func(tx *bolt.Tx) error {
+			c := tx.Bucket(bucketName).Cursor()
+
+			k, v := c.First()
+			if after != nil {
+				// Resume after the last key of the previous batch
+				k, v = c.Seek(after)
+				if bytes.Equal(k, after) {
+					k, v = c.Next()
+				}
+			}
+
+			for read := 0; k != nil; k, v = c.Next() {
+				if read == eachBatchSize {
+					done = false
+					break
+				}
+				read++
+				// Keys are only valid inside the transaction
+				after = append(after[:0], k...)
+
+				submission, err := s.match(filter, v)
+				if err != nil {
+					return fmt.Errorf("failed to decode submission %s: %w", k, err)
+				}
+				if submission != nil {
+					batch = append(batch, submission)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, submission := range batch {
+			if err := fn(submission); err != nil {
+				return err
+			}
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// match decodes a stored submission and returns it if it passes filter, or
+// nil if it doesn't. Encrypted records are compared to an email filter by
+// their blind index, so non-matching ones are never decrypted.
+func (s *boltSubmissions) match(filter entity.SubmissionFilter, v []byte) (*entity.Submission, error) {
+	var rec record
+	if err := json.Unmarshal(v, &rec); err != nil {
+		return nil, err
+	}
+	if filter.Email != "" && !rec.HasEmail(s.keys, filter.Email) {
+		return nil, nil
+	}
+	if err := rec.open(s.keys); err != nil {
+		return nil, err
+	}
+
+	submission := rec.toSubmission()
+	if !filter.Matches(submission) {
+		return nil, nil
+	}
+	return submission, nil
+}
+
+// MarkSent records the successful delivery of a submission
+func (s *boltSubmissions) MarkSent(id string, receipt *entity.DeliveryReceipt, attempts int) error {
+	return s.update(id, func(rec *record) {
+		rec.Status = string(entity.SubmissionSent)
+		rec.Attempts = attempts
+		rec.Reason = ""
+		if receipt != nil {
+			rec.Provider = receipt.Provider
+			rec.MessageID = receipt.MessageID
+		}
+	})
+}
+
+// AddAttempt appends a delivery attempt to the submission's history
+func (s *boltSubmissions) AddAttempt(id string, attempt entity.DeliveryAttempt) error {
+	return s.update(id, func(rec *record) {
+		rec.History = append(rec.History, newAttemptRecord(attempt))
+	})
+}
+
+// MarkFailed records that delivery of a submission was given up
+func (s *boltSubmissions) MarkFailed(id string, reason string, attempts int) error {
+	return s.update(id, func(rec *record) {
+		rec.Status = string(entity.SubmissionFailed)
+		rec.Attempts = attempts
+		rec.Reason = reason
+	})
+}
+
+// SetLabel records the spam label a submission was trained with
+func (s *boltSubmissions) SetLabel(id string, label entity.SpamLabel) error {
+	return s.update(id, func(rec *record) {
+		rec.Label = string(label)
+	})
+}
+
+// Delete removes a submission
+func (s *boltSubmissions) Delete(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Delete([]byte(id))
+	})
+}
+
+// DeleteByEmail removes every submission from the given submitter address
+func (s *boltSubmissions) DeleteByEmail(email string) ([]*entity.Submission, error) {
+	var removed []*entity.Submission
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		matches, err := collect(tx.Bucket(bucketName), func(rec *record) bool {
+			return rec.HasEmail(s.keys, email)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, rec := range matches {
+			if err := tx.Bucket(bucketName).Delete([]byte(rec.ID)); err != nil {
+				return err
+			}
+			if err := rec.open(s.keys); err != nil {
+				return err
+			}
+			removed = append(removed, rec.toSubmission())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete submissions: %w", err)
+	}
+
+	return removed, nil
+}
+
+// DeleteBefore removes submissions submitted before the given time
+func (s *boltSubmissions) DeleteBefore(before time.Time) (int, error) {
+	count := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		matches, err := collect(tx.Bucket(bucketName), func(rec *record) bool {
+			return rec.SubmittedAt.Before(before)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, rec := range matches {
+			if err := tx.Bucket(bucketName).Delete([]byte(rec.ID)); err != nil {
+				return err
+			}
+		}
+		count = len(matches)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete submissions: %w", err)
+	}
+
+	return count, nil
+}
+
+// AnonymiseBefore anonymises submissions submitted before the given time
+func (s *boltSubmissions) AnonymiseBefore(before time.Time) (int, error) {
+	count := 0
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		matches, err := collect(b, func(rec *record) bool {
+			return !rec.Anonymised && rec.SubmittedAt.Before(before)
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		for _, rec := range matches {
+			if err := rec.open(s.keys); err != nil {
+				return err
+			}
+			submission := rec.toSubmission()
+			submission.Anonymise()
+
+			anonymised := newRecord(submission)
+			anonymised.UpdatedAt = now
+			if err := put(b, s.keys, anonymised); err != nil {
+				return err
+			}
+		}
+		count = len(matches)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymise submissions: %w", err)
+	}
+
+	return count, nil
+}
+
+// update applies fn to a stored record
+func (s *boltSubmissions) update(id string, fn func(rec *record)) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		rec, err := get(b, s.keys, id)
+		if err != nil {
+			return err
+		}
+
+		fn(rec)
+		rec.UpdatedAt = time.Now().UTC()
+		return put(b, s.keys, rec)
+	})
+}
+
+// newRecord converts a submission into its persisted form
+func newRecord(submission *entity.Submission) *record {
+	rec := &record{
+		ContactRecord: storage.NewContactRecord(submission.Contact),
+		ClientIP:      submission.ClientIP,
+		UserAgent:     submission.UserAgent,
+		Origin:        submission.Origin,
+		Status:        string(submission.Status),
+		Provider:      submission.Provider,
+		MessageID:     submission.MessageID,
+		Attempts:      submission.Attempts,
+		Reason:        submission.Reason,
+		Label:         string(submission.Label),
+		Anonymised:    submission.Anonymised,
+		UpdatedAt:     submission.UpdatedAt,
+	}
+
+	for _, a := range submission.History {
+		rec.History = append(rec.History, newAttemptRecord(a))
+	}
+	return rec
+}
+
+// newAttemptRecord converts a delivery attempt into its persisted form
+func newAttemptRecord(attempt entity.DeliveryAttempt) attemptRecord {
+	return attemptRecord{
+		At:        attempt.At,
+		Resend:    attempt.Resend,
+		To:        attempt.Recipients.To,
+		CC:        attempt.Recipients.CC,
+		BCC:       attempt.Recipients.BCC,
+		Provider:  attempt.Provider,
+		MessageID: attempt.MessageID,
+		Error:     attempt.Error,
+	}
+}
+
+// toSubmission converts a record back into a domain entity
+func (r *record) toSubmission() *entity.Submission {
+	var history []entity.DeliveryAttempt
+	for _, a := range r.History {
+		history = append(history, entity.DeliveryAttempt{
+			At:         a.At,
+			Resend:     a.Resend,
+			Recipients: entity.Recipients{To: a.To, CC: a.CC, BCC: a.BCC},
+			Provider:   a.Provider,
+			MessageID:  a.MessageID,
+			Error:      a.Error,
+		})
+	}
+
+	return &entity.Submission{
+		Contact:    r.ToContact(),
+		ClientIP:   r.ClientIP,
+		UserAgent:  r.UserAgent,
+		Origin:     r.Origin,
+		Status:     entity.SubmissionStatus(r.Status),
+		Provider:   r.Provider,
+		MessageID:  r.MessageID,
+		Attempts:   r.Attempts,
+		Reason:     r.Reason,
+		History:    history,
+		Label:      entity.SpamLabel(r.Label),
+		Anonymised: r.Anonymised,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+// Reencrypt rewrites every submission with the active key of keys and a fresh
+// email index, and returns how many submissions were rewritten
+func Reencrypt(db *bolt.DB, keys *encryption.Keyring) (int, error) {
+	count := 0
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		if b == nil {
+			return nil
+		}
+
+		records, err := collect(b, func(*record) bool { return true })
+		if err != nil {
+			return err
+		}
+
+		for _, rec := range records {
+			if err := rec.open(keys); err != nil {
+				return err
+			}
+			if err := put(b, keys, rec); err != nil {
+				return err
+			}
+		}
+		count = len(records)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to re-encrypt submissions: %w", err)
+	}
+
+	return count, nil
+}
+
+// collect returns the still encrypted records for which match returns true.
+// Callers delete afterwards, since deleting while a cursor walks the bucket
+// can skip keys.
+func collect(b *bolt.Bucket, match func(rec *record) bool) ([]*record, error) {
+	var matches []*record
+
+	err := b.ForEach(func(k, v []byte) error {
+		var rec record
+		if err := json.Unmarshal(v, &rec); err != nil {
+			return fmt.Errorf("failed to decode submission %s: %w", k, err)
+		}
+		if match(&rec) {
+			matches = append(matches, &rec)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return matches, nil
+}
+
+// open decrypts the record in place, including the submission's own personal fields
+func (r *record) open(keys *encryption.Keyring) error {
+	return r.Open(keys, r.sealedExtra())
+}
+
+// sealedExtra returns the personal fields the record adds to the contact
+func (r *record) sealedExtra() map[string]*string {
+	return map[string]*string{
+		"client_ip":  &r.ClientIP,
+		"user_agent": &r.UserAgent,
+	}
+}
+
+// get loads and decrypts a record by ID
+func get(b *bolt.Bucket, keys *encryption.Keyring, id string) (*record, error) {
+	v := b.Get([]byte(id))
+	if v == nil {
+		return nil, fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+	}
+
+	var rec record
+	if err := json.Unmarshal(v, &rec); err != nil {
+		return nil, err
+	}
+	if err := rec.open(keys); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// put encrypts a copy of the record and stores it under its ID
+func put(b *bolt.Bucket, keys *encryption.Keyring, rec *record) error {
+	sealed := *rec
+	sealed.ContactRecord = rec.Copy()
+	if err := sealed.Seal(keys, sealed.sealedExtra()); err != nil {
+		return err
+	}
+
+	v, err := json.Marshal(&sealed)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(rec.ID), v)
+}
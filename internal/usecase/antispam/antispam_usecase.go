@@ -0,0 +1,131 @@
+package antispam
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// antiSpamUseCase implements the UseCase interface
+type antiSpamUseCase struct {
+	secret []byte
+	policy Policy
+}
+
+// NewAntiSpamUseCase creates a new anti-spam use case. Tokens are signed with
+// secret; without one a random secret is used, so tokens don't survive a restart
+// and aren't accepted by other instances.
+func NewAntiSpamUseCase(secret []byte, policy Policy) (UseCase, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, sha256.Size)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate token secret: %w", err)
+		}
+	}
+
+	return &antiSpamUseCase{
+		secret: secret,
+		policy: policy,
+	}, nil
+}
+
+// IssueToken returns a signed token recording when the form was rendered
+func (uc *antiSpamUseCase) IssueToken(ctx context.Context) (*Token, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	issued := strconv.FormatInt(now.Unix(), 10)
+
+	return &Token{
+		Value:     issued + "." + uc.sign(issued),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(uc.policy.TokenMaxAge),
+	}, nil
+}
+
+// Inspect checks the submitted values for bot behaviour
+func (uc *antiSpamUseCase) Inspect(ctx context.Context, input InspectInput) (*Verdict, error) {
+	token := input.Values[TokenField]
+	delete(input.Values, TokenField)
+
+	var honeypot string
+	if field := uc.policy.HoneypotField; field != "" {
+		honeypot = input.Values[field]
+		delete(input.Values, field)
+	}
+
+	if input.Trusted {
+		return &Verdict{}, nil
+	}
+
+	reason, err := uc.check(token, honeypot)
+	if err != nil || reason == "" {
+		return &Verdict{}, err
+	}
+
+	log.Printf("[AntiSpamUseCase] Dropped submission: %s", reason)
+	return &Verdict{
+		Spam:    true,
+		Reason:  reason,
+		DecoyID: uuid.Must(uuid.NewV7()).String(),
+	}, nil
+}
+
+// check returns why a submission looks automated, or "" when it passes
+func (uc *antiSpamUseCase) check(token, honeypot string) (string, error) {
+	if strings.TrimSpace(honeypot) != "" {
+		return "honeypot field filled", nil
+	}
+
+	if uc.policy.MinSubmitTime <= 0 {
+		return "", nil
+	}
+
+	// A visitor who loaded the form always has a token, so a missing or
+	// forged one is treated like any other bot tell
+	if token == "" {
+		return "missing form token", nil
+	}
+	issuedAt, ok := uc.verify(token)
+	if !ok {
+		return "invalid form token", nil
+	}
+
+	elapsed := time.Since(issuedAt)
+	if elapsed < uc.policy.MinSubmitTime {
+		return fmt.Sprintf("submitted %v after the form was rendered", elapsed.Round(time.Millisecond)), nil
+	}
+	if uc.policy.TokenMaxAge > 0 && elapsed > uc.policy.TokenMaxAge {
+		return "", ErrTokenExpired
+	}
+
+	return "", nil
+}
+
+// verify checks a token's signature and returns when it was issued
+func (uc *antiSpamUseCase) verify(token string) (time.Time, bool) {
+	issued, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(uc.sign(issued))) {
+		return time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(issued, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// sign returns the signature of a token payload
+func (uc *antiSpamUseCase) sign(payload string) string {
+	mac := hmac.New(sha256.New, uc.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
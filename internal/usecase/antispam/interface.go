@@ -0,0 +1,51 @@
+package antispam
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TokenField is the submitted field carrying the form token from GET /api/contact/token
+const TokenField = "form_token"
+
+// ErrTokenExpired is returned for a genuine form token older than the maximum
+// age; the visitor has to reload the form rather than being silently dropped
+var ErrTokenExpired = errors.New("form token expired")
+
+// Policy configures the spam checks. Zero values disable a check.
+type Policy struct {
+	HoneypotField string        // hidden field that people leave empty
+	MinSubmitTime time.Duration // minimum time between issuing a form token and submitting
+	TokenMaxAge   time.Duration // how long a form token stays valid
+}
+
+// Token is a signed "form rendered at" timestamp
+type Token struct {
+	Value     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// InspectInput represents a submission to check
+type InspectInput struct {
+	Values  map[string]string // submitted field values
+	Trusted bool              // sent with an API key; fields are stripped but not checked
+}
+
+// Verdict is the outcome of the spam checks
+type Verdict struct {
+	Spam    bool
+	Reason  string // why the submission was classed as spam
+	DecoyID string // submission ID to answer a dropped submission with
+}
+
+// UseCase defines the anti-spam use case interface
+type UseCase interface {
+	// IssueToken returns a signed token recording when the form was rendered
+	IssueToken(ctx context.Context) (*Token, error)
+
+	// Inspect checks the submitted values for bot behaviour. The honeypot and
+	// token fields are removed from the values so they never reach the contact.
+	Inspect(ctx context.Context, input InspectInput) (*Verdict, error)
+}
@@ -0,0 +1,147 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// tokenPrefix marks API key tokens so they are easy to recognise in secret scanners
+const tokenPrefix = "msk"
+
+// touchInterval is how stale a key's last-used time may get before it's written again
+const touchInterval = time.Minute
+
+// apiKeyUseCase implements the UseCase interface
+type apiKeyUseCase struct {
+	keys             repository.APIKeyRepository
+	defaultRateLimit int
+}
+
+// NewAPIKeyUseCase creates a new API key use case
+func NewAPIKeyUseCase(keys repository.APIKeyRepository, defaultRateLimit int) UseCase {
+	return &apiKeyUseCase{
+		keys:             keys,
+		defaultRateLimit: defaultRateLimit,
+	}
+}
+
+// Create issues a new API key
+func (uc *apiKeyUseCase) Create(ctx context.Context, input CreateInput) (*CreateOutput, error) {
+	rateLimit := input.RateLimit
+	if rateLimit == 0 {
+		rateLimit = uc.defaultRateLimit
+	}
+
+	id, err := randomString(6, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomString(32, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	key := &entity.APIKey{
+		ID:         id,
+		Name:       strings.TrimSpace(input.Name),
+		SecretHash: hashSecret(secret),
+		Scopes:     input.Scopes,
+		RateLimit:  rateLimit,
+		CreatedAt:  time.Now().UTC(),
+	}
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeySpec, err)
+	}
+
+	if err := uc.keys.Create(key); err != nil {
+		return nil, fmt.Errorf("failed to store api key: %w", err)
+	}
+
+	log.Printf("[APIKeyUseCase] Created API key %s (%s) with scopes %v", key.ID, key.Name, key.Scopes)
+	return &CreateOutput{
+		Key:   key,
+		Token: tokenPrefix + "_" + id + "_" + secret,
+	}, nil
+}
+
+// List returns all API keys
+func (uc *apiKeyUseCase) List(ctx context.Context) ([]*entity.APIKey, error) {
+	return uc.keys.List()
+}
+
+// Revoke deletes an API key
+func (uc *apiKeyUseCase) Revoke(ctx context.Context, id string) error {
+	if err := uc.keys.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+
+	log.Printf("[APIKeyUseCase] Revoked API key %s", id)
+	return nil
+}
+
+// Authenticate returns the key a token belongs to if it grants scope
+func (uc *apiKeyUseCase) Authenticate(ctx context.Context, token, scope string) (*entity.APIKey, error) {
+	// Tokens are msk_<id>_<secret>; the secret may itself contain underscores
+	parts := strings.SplitN(token, "_", 3)
+	if len(parts) != 3 || parts[0] != tokenPrefix {
+		return nil, ErrInvalidAPIKey
+	}
+
+	key, err := uc.keys.Get(parts[1])
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(parts[2])), []byte(key.SecretHash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+	if !key.Allows(scope) {
+		return nil, ErrScopeDenied
+	}
+
+	// Keep last-used roughly current without a write on every request
+	now := time.Now().UTC()
+	if now.Sub(key.LastUsedAt) >= touchInterval {
+		if err := uc.keys.Touch(key.ID, now); err != nil {
+			log.Printf("[APIKeyUseCase] Failed to record use of API key %s: %v", key.ID, err)
+		} else {
+			key.LastUsedAt = now
+		}
+	}
+
+	return key, nil
+}
+
+// hashSecret returns the stored form of a key secret. The secret is random
+// and long, so a fast hash is enough.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomString returns n random bytes encoded with encode
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return encode(b), nil
+}
@@ -0,0 +1,44 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// API key use case errors
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrScopeDenied    = errors.New("api key is not allowed to use this endpoint")
+	ErrInvalidKeySpec = errors.New("invalid api key definition")
+)
+
+// CreateInput represents a new API key
+type CreateInput struct {
+	Name      string
+	Scopes    []string
+	RateLimit int // requests per minute; defaults to the server's default
+}
+
+// CreateOutput holds a new API key and the only copy of its token
+type CreateOutput struct {
+	Key   *entity.APIKey
+	Token string
+}
+
+// UseCase defines the API key use case interface
+type UseCase interface {
+	// Create issues a new API key. The token is returned once and never stored.
+	Create(ctx context.Context, input CreateInput) (*CreateOutput, error)
+
+	// List returns all API keys, oldest first
+	List(ctx context.Context) ([]*entity.APIKey, error)
+
+	// Revoke deletes an API key
+	Revoke(ctx context.Context, id string) error
+
+	// Authenticate returns the key a token belongs to if it grants scope
+	Authenticate(ctx context.Context, token, scope string) (*entity.APIKey, error)
+}
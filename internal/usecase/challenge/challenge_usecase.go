@@ -0,0 +1,146 @@
+package challenge
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// nonceSize is the number of random bytes in a challenge nonce
+const nonceSize = 16
+
+// challengeUseCase implements the UseCase interface
+type challengeUseCase struct {
+	secret []byte
+	policy Policy
+	used   *usedNonces
+	volume *submissionVolume
+}
+
+// NewChallengeUseCase creates a new proof-of-work challenge use case.
+// Challenges are signed with secret; without one a random secret is used,
+// so challenges don't survive a restart and aren't accepted by other instances.
+func NewChallengeUseCase(secret []byte, policy Policy) (UseCase, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, sha256.Size)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate challenge secret: %w", err)
+		}
+	}
+
+	volume, err := newSubmissionVolume(policy.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	return &challengeUseCase{
+		secret: secret,
+		policy: policy,
+		used:   newUsedNonces(),
+		volume: volume,
+	}, nil
+}
+
+// Issue returns a new challenge for the client
+func (uc *challengeUseCase) Issue(ctx context.Context, clientIP string) (*Challenge, error) {
+	raw := make([]byte, nonceSize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+
+	nonce := base64.RawURLEncoding.EncodeToString(raw)
+	difficulty := uc.difficulty(clientIP)
+	expiresAt := time.Now().UTC().Add(uc.policy.TTL).Truncate(time.Second)
+
+	payload := strings.Join([]string{nonce, strconv.Itoa(difficulty), strconv.FormatInt(expiresAt.Unix(), 10)}, ".")
+	return &Challenge{
+		Value:      payload + "." + uc.sign(payload, clientIP),
+		Nonce:      nonce,
+		Difficulty: difficulty,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+// Verify checks a solved challenge and records it so it can't be replayed
+func (uc *challengeUseCase) Verify(ctx context.Context, input VerifyInput) error {
+	if input.Challenge == "" {
+		return ErrChallengeRequired
+	}
+
+	// Challenges are nonce.difficulty.expires.signature, signed for the client's IP
+	parts := strings.Split(input.Challenge, ".")
+	if len(parts) != 4 {
+		return ErrChallengeInvalid
+	}
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(uc.sign(payload, input.ClientIP))) {
+		return ErrChallengeInvalid
+	}
+
+	nonce := parts[0]
+	difficulty, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return ErrChallengeInvalid
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return ErrChallengeInvalid
+	}
+	expiresAt := time.Unix(expires, 0)
+	if time.Now().After(expiresAt) {
+		return ErrChallengeExpired
+	}
+
+	solved := leadingZeroBits(sha256.Sum256([]byte(nonce + input.Solution)))
+	if solved < difficulty {
+		return ErrSolutionInvalid
+	}
+	// Challenges fetched in bulk before a burst of submissions don't get
+	// the lower difficulty they were issued with
+	if solved < uc.difficulty(input.ClientIP) {
+		return ErrChallengeExpired
+	}
+
+	if !uc.used.Use(nonce, expiresAt) {
+		return ErrChallengeReplayed
+	}
+
+	uc.volume.Record(input.ClientIP)
+	return nil
+}
+
+// difficulty returns the number of leading zero bits required from a client:
+// each submission from its IP within the window doubles the work
+func (uc *challengeUseCase) difficulty(clientIP string) int {
+	difficulty := uc.policy.BaseDifficulty + uc.volume.Count(clientIP)
+	if difficulty > uc.policy.MaxDifficulty {
+		difficulty = uc.policy.MaxDifficulty
+	}
+	return difficulty
+}
+
+// sign returns the signature of a challenge payload issued to clientIP
+func (uc *challengeUseCase) sign(payload, clientIP string) string {
+	mac := hmac.New(sha256.New, uc.secret)
+	mac.Write([]byte(payload + "|" + clientIP))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// leadingZeroBits counts the leading zero bits of a hash
+func leadingZeroBits(sum [sha256.Size]byte) int {
+	n := 0
+	for _, b := range sum {
+		if b != 0 {
+			return n + bits.LeadingZeros8(b)
+		}
+		n += 8
+	}
+	return n
+}
@@ -0,0 +1,59 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Submitted fields carrying a solved challenge
+const (
+	ChallengeField = "pow_challenge"
+	SolutionField  = "pow_solution"
+)
+
+// Challenge use case errors
+var (
+	ErrChallengeRequired = errors.New("proof-of-work challenge is required")
+	ErrChallengeInvalid  = errors.New("proof-of-work challenge is invalid")
+	ErrChallengeReplayed = errors.New("proof-of-work challenge was already used")
+	ErrSolutionInvalid   = errors.New("proof-of-work solution is invalid")
+
+	// ErrChallengeExpired is returned when the challenge is too old, or the
+	// client's difficulty has risen since it was issued; a new one is needed
+	ErrChallengeExpired = errors.New("proof-of-work challenge expired")
+)
+
+// Policy configures proof-of-work challenges
+type Policy struct {
+	BaseDifficulty int           // leading zero bits required from a quiet client
+	MaxDifficulty  int           // cap on the adaptive difficulty
+	TTL            time.Duration // how long a challenge can be solved
+	Window         time.Duration // how far back submissions from the same IP are counted
+}
+
+// Challenge is a signed proof-of-work puzzle. A solution is any string that,
+// appended to Nonce, gives a SHA-256 hash with Difficulty leading zero bits.
+type Challenge struct {
+	Value      string // signed challenge to send back with the solution
+	Nonce      string
+	Difficulty int
+	ExpiresAt  time.Time
+}
+
+// VerifyInput represents a solved challenge sent with a submission
+type VerifyInput struct {
+	Challenge string
+	Solution  string
+	ClientIP  string
+}
+
+// UseCase defines the proof-of-work challenge use case interface
+type UseCase interface {
+	// Issue returns a new challenge for the client, harder the more the
+	// client has submitted recently
+	Issue(ctx context.Context, clientIP string) (*Challenge, error)
+
+	// Verify checks a solved challenge and records it so it can't be replayed
+	Verify(ctx context.Context, input VerifyInput) error
+}
@@ -0,0 +1,41 @@
+package challenge
+
+import (
+	"sync"
+	"time"
+)
+
+// usedNonces remembers solved challenges until they expire, so each one
+// can only be used for a single submission
+type usedNonces struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+// newUsedNonces creates an empty used-nonce cache
+func newUsedNonces() *usedNonces {
+	return &usedNonces{
+		until: make(map[string]time.Time),
+	}
+}
+
+// Use records a nonce as used until expiresAt and reports whether it was unused
+func (u *usedNonces) Use(nonce string, expiresAt time.Time) bool {
+	now := time.Now()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// Expired challenges are rejected anyway, so their nonces can be forgotten
+	for n, until := range u.until {
+		if now.After(until) {
+			delete(u.until, n)
+		}
+	}
+
+	if _, ok := u.until[nonce]; ok {
+		return false
+	}
+	u.until[nonce] = expiresAt
+	return true
+}
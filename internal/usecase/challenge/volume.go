@@ -0,0 +1,84 @@
+package challenge
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// submissionVolume counts recent submissions per client IP. IPs are kept as
+// HMACs under a random key that never leaves the process, so the counts can't
+// be traced back to addresses by hashing candidates.
+type submissionVolume struct {
+	window time.Duration
+	key    []byte
+
+	mu          sync.Mutex
+	submissions map[[sha256.Size]byte][]time.Time
+}
+
+// newSubmissionVolume creates a counter over the given window
+func newSubmissionVolume(window time.Duration) (*submissionVolume, error) {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate submission volume key: %w", err)
+	}
+
+	return &submissionVolume{
+		window:      window,
+		key:         key,
+		submissions: make(map[[sha256.Size]byte][]time.Time),
+	}, nil
+}
+
+// Record counts a submission from clientIP
+func (v *submissionVolume) Record(clientIP string) {
+	key := v.hash(clientIP)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	v.prune(now)
+	v.submissions[key] = append(v.submissions[key], now)
+}
+
+// Count returns the number of submissions from clientIP within the window
+func (v *submissionVolume) Count(clientIP string) int {
+	key := v.hash(clientIP)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.prune(time.Now())
+	return len(v.submissions[key])
+}
+
+// hash returns the keyed hash clientIP is counted under
+func (v *submissionVolume) hash(clientIP string) [sha256.Size]byte {
+	var key [sha256.Size]byte
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(clientIP))
+	copy(key[:], mac.Sum(nil))
+	return key
+}
+
+// prune forgets submissions older than the window
+func (v *submissionVolume) prune(now time.Time) {
+	for key, times := range v.submissions {
+		recent := times[:0]
+		for _, at := range times {
+			if now.Sub(at) < v.window {
+				recent = append(recent, at)
+			}
+		}
+		if len(recent) == 0 {
+			delete(v.submissions, key)
+		} else {
+			v.submissions[key] = recent
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package classifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// classifierUseCase implements the UseCase interface
+type classifierUseCase struct {
+	submissions repository.SubmissionRepository
+	classifier  repository.SpamClassifier
+
+	// mu serialises labelling, so a submission is never trained twice
+	mu sync.Mutex
+}
+
+// NewClassifierUseCase creates a new spam classifier training use case
+func NewClassifierUseCase(
+	submissions repository.SubmissionRepository,
+	classifier repository.SpamClassifier,
+) UseCase {
+	return &classifierUseCase{
+		submissions: submissions,
+		classifier:  classifier,
+	}
+}
+
+// Label trains the classifier with a stored submission
+func (uc *classifierUseCase) Label(ctx context.Context, input LabelInput) (*LabelOutput, error) {
+	if input.Label != entity.SpamLabelSpam && input.Label != entity.SpamLabelHam {
+		return nil, ErrInvalidLabel
+	}
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	submission, err := uc.submissions.Get(input.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSubmissionNotFound, input.ID)
+		}
+		return nil, err
+	}
+
+	output := &LabelOutput{Previous: submission.Label}
+	if submission.Label == input.Label {
+		output.Stats = uc.classifier.Stats()
+		return output, nil
+	}
+
+	// Anonymised submissions have nothing left to learn from or to unlearn
+	contact := submission.Contact
+	if submission.Anonymised || (contact.Subject == "" && contact.Message == "") {
+		return nil, ErrNothingToLearn
+	}
+
+	if submission.Label != entity.SpamLabelNone {
+		uc.classifier.Unlearn(contact, submission.Label == entity.SpamLabelSpam)
+	}
+	uc.classifier.Learn(contact, input.Label == entity.SpamLabelSpam)
+
+	if err := uc.submissions.SetLabel(contact.ID, input.Label); err != nil {
+		// Undo the training so a retry doesn't count the submission twice
+		uc.classifier.Unlearn(contact, input.Label == entity.SpamLabelSpam)
+		if submission.Label != entity.SpamLabelNone {
+			uc.classifier.Learn(contact, submission.Label == entity.SpamLabelSpam)
+		}
+		return nil, fmt.Errorf("failed to label submission: %w", err)
+	}
+
+	// The training is kept in memory and saved with the next label if this fails
+	if err := uc.classifier.Save(); err != nil {
+		log.Printf("[ClassifierUseCase] Failed to save spam classifier: %v", err)
+	}
+
+	output.Stats = uc.classifier.Stats()
+	log.Printf("[ClassifierUseCase] Submission %s labelled %s (%d spam, %d ham learned)",
+		contact.ID, input.Label, output.Stats.SpamMessages, output.Stats.HamMessages)
+	return output, nil
+}
+
+// Stats returns what the classifier has learned
+func (uc *classifierUseCase) Stats(ctx context.Context) entity.ClassifierStats {
+	return uc.classifier.Stats()
+}
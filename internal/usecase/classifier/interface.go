@@ -0,0 +1,37 @@
+package classifier
+
+import (
+	"context"
+	"errors"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// Classifier use case errors
+var (
+	ErrSubmissionNotFound = errors.New("submission not found")
+	ErrInvalidLabel       = errors.New("label must be spam or ham")
+	ErrNothingToLearn     = errors.New("submission has no subject or message to learn from")
+)
+
+// LabelInput represents an admin's verdict on a stored submission
+type LabelInput struct {
+	ID    string
+	Label entity.SpamLabel // spam or ham
+}
+
+// LabelOutput represents the outcome of labelling a submission
+type LabelOutput struct {
+	Previous entity.SpamLabel // label the submission had before
+	Stats    entity.ClassifierStats
+}
+
+// UseCase defines the spam classifier training use case interface
+type UseCase interface {
+	// Label trains the classifier with a stored submission and records the
+	// label on it. Relabelling first reverses the earlier training.
+	Label(ctx context.Context, input LabelInput) (*LabelOutput, error)
+
+	// Stats returns what the classifier has learned
+	Stats(ctx context.Context) entity.ClassifierStats
+}
@@ -0,0 +1,55 @@
+package contact
+
+import (
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AutoReplyPolicy controls the confirmation email sent to the submitter
+type AutoReplyPolicy struct {
+	Enabled  bool
+	Interval time.Duration // minimum time between auto-replies to the same address
+}
+
+// replyThrottle limits auto-replies to one per address per interval, so the
+// form can't be used to flood an arbitrary mailbox. Addresses are only kept
+// as hashes, so no readable submitter address stays in memory.
+type replyThrottle struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	sent map[[sha256.Size]byte]time.Time
+}
+
+// newReplyThrottle creates a throttle allowing one reply per address per interval
+func newReplyThrottle(interval time.Duration) *replyThrottle {
+	return &replyThrottle{
+		interval: interval,
+		sent:     make(map[[sha256.Size]byte]time.Time),
+	}
+}
+
+// Allow reports whether address may receive a reply now and, if so,
+// records the reply so further ones are held back until the interval passes
+func (t *replyThrottle) Allow(address string) bool {
+	key := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(address))))
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Forget expired entries so the map only holds addresses still throttled
+	for k, at := range t.sent {
+		if now.Sub(at) >= t.interval {
+			delete(t.sent, k)
+		}
+	}
+
+	if _, ok := t.sent[key]; ok {
+		return false
+	}
+	t.sent[key] = now
+	return true
+}
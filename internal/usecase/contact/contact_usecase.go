@@ -2,21 +2,61 @@ package contact
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
 	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+
+	"github.com/google/uuid"
 )
 
 // contactUseCase implements the UseCase interface
 type contactUseCase struct {
-	emailRepo repository.EmailRepository
+	emailRepo     repository.EmailRepository
+	outboxRepo    repository.OutboxRepository
+	submissions   repository.SubmissionRepository
+	formRepo      repository.FormRepository
+	routes        *entity.RoutingTable
+	deliveryQueue repository.DeliveryQueue
+	retryPolicy   RetryPolicy
+	autoReply     AutoReplyPolicy
+	replyThrottle *replyThrottle
+	spam          SpamPolicy
 }
 
+// resumeRetryInterval is how long ResumePending waits when the queue is full
+const resumeRetryInterval = 500 * time.Millisecond
+
 // NewContactUseCase creates a new contact use case
-func NewContactUseCase(emailRepo repository.EmailRepository) UseCase {
+func NewContactUseCase(
+	emailRepo repository.EmailRepository,
+	outboxRepo repository.OutboxRepository,
+	submissions repository.SubmissionRepository,
+	formRepo repository.FormRepository,
+	routes *entity.RoutingTable,
+	deliveryQueue repository.DeliveryQueue,
+	retryPolicy RetryPolicy,
+	autoReply AutoReplyPolicy,
+	spam SpamPolicy,
+) UseCase {
+	if retryPolicy.MaxAttempts < 1 {
+		retryPolicy.MaxAttempts = 1
+	}
+
 	return &contactUseCase{
-		emailRepo: emailRepo,
+		emailRepo:     emailRepo,
+		outboxRepo:    outboxRepo,
+		submissions:   submissions,
+		formRepo:      formRepo,
+		routes:        routes,
+		deliveryQueue: deliveryQueue,
+		retryPolicy:   retryPolicy,
+		autoReply:     autoReply,
+		replyThrottle: newReplyThrottle(autoReply.Interval),
+		spam:          spam,
 	}
 }
 
@@ -37,18 +77,258 @@ func (uc *contactUseCase) SendContact(ctx context.Context, input *ContactInput)
 		}, err
 	}
 
-	// Send email via repository
-	if err := uc.emailRepo.Send(contact); err != nil {
-		log.Printf("[UseCase] Failed to send email: %v", err)
+	return uc.queue(contact, input.Client)
+}
+
+// SubmitForm validates a submission against a configured form and queues it for delivery
+func (uc *contactUseCase) SubmitForm(ctx context.Context, input *FormInput) (*ContactOutput, error) {
+	form, err := uc.formRepo.Get(input.FormID)
+	if err != nil {
+		log.Printf("[UseCase] Form lookup failed for %q: %v", input.FormID, err)
+		return &ContactOutput{
+			Success: false,
+			Message: "Form not found",
+		}, fmt.Errorf("%w: %v", ErrFormNotFound, err)
+	}
+
+	contact, err := form.NewContact(input.Values)
+	if err != nil {
+		log.Printf("[UseCase] Validation failed for form %s: %v", form.ID, err)
 		return &ContactOutput{
 			Success: false,
-			Message: "Failed to send email. Please try again later.",
+			Message: err.Error(),
 		}, err
 	}
 
-	log.Printf("[UseCase] Email sent successfully from %s (%s)", contact.Name, contact.Email)
+	return uc.queue(contact, input.Client)
+}
+
+// queue scores and routes a validated contact, records it in the submission
+// history and the outbox, and hands it to the delivery queue. Contacts scored
+// for quarantine are only recorded.
+func (uc *contactUseCase) queue(contact *entity.Contact, client ClientInfo) (*ContactOutput, error) {
+	contact.ID = newSubmissionID()
+	contact.SubmittedAt = time.Now().UTC()
+
+	contact.Spam = uc.spam.Score(contact)
+	if contact.Spam != nil {
+		log.Printf("[UseCase] Contact %s spam score %s", contact.ID, describeSpamScore(contact.Spam))
+		if contact.Spam.Action == entity.SpamActionReject {
+			return &ContactOutput{
+				Success: false,
+				Message: "Message rejected as spam",
+			}, ErrSpamRejected
+		}
+	}
+	quarantined := contact.Spam != nil && contact.Spam.Action == entity.SpamActionQuarantine
+
+	// Choose the recipients now so replays and dead letters keep the same route
+	route := uc.routes.Route(contact)
+	contact.Recipients = route.Recipients
+	contact.Route = route.Name
+	log.Printf("[UseCase] Contact %s routed via %s to %s", contact.ID, route.Name, route.Recipients)
+
+	status := entity.SubmissionQueued
+	if quarantined {
+		status = entity.SubmissionQuarantined
+	}
+
+	// Keep a record of every accepted submission, whatever happens to the email
+	err := uc.submissions.Create(&entity.Submission{
+		Contact:   contact,
+		ClientIP:  client.IP,
+		UserAgent: client.UserAgent,
+		Origin:    client.Origin,
+		Status:    status,
+	})
+	if err != nil {
+		log.Printf("[UseCase] Failed to store submission %s: %v", contact.ID, err)
+		return &ContactOutput{
+			Success: false,
+			Message: "Service is busy. Please try again later.",
+		}, fmt.Errorf("%w: %v", ErrDeliveryUnavailable, err)
+	}
+
+	// Quarantined contacts wait in the history for an admin to resend them;
+	// the submitter gets the usual answer
+	if quarantined {
+		uc.spam.Record(contact)
+		log.Printf("[UseCase] Contact %s quarantined", contact.ID)
+		return &ContactOutput{
+			Success:      true,
+			Message:      AcceptedMessage,
+			SubmissionID: contact.ID,
+		}, nil
+	}
+
+	// Record in the outbox so the contact survives a restart
+	if err := uc.outboxRepo.Add(contact); err != nil {
+		log.Printf("[UseCase] Failed to record contact %s in outbox: %v", contact.ID, err)
+		uc.discardSubmission(contact.ID)
+		return &ContactOutput{
+			Success: false,
+			Message: "Service is busy. Please try again later.",
+		}, fmt.Errorf("%w: %v", ErrDeliveryUnavailable, err)
+	}
+
+	// Hand off to the delivery queue; workers call Deliver
+	if err := uc.deliveryQueue.Enqueue(contact); err != nil {
+		log.Printf("[UseCase] Failed to enqueue contact %s: %v", contact.ID, err)
+		// The visitor is told to retry, so this copy must not be replayed
+		if markErr := uc.outboxRepo.Remove(contact.ID); markErr != nil {
+			log.Printf("[UseCase] Failed to update outbox for contact %s: %v", contact.ID, markErr)
+		}
+		uc.discardSubmission(contact.ID)
+		return &ContactOutput{
+			Success: false,
+			Message: "Service is busy. Please try again later.",
+		}, fmt.Errorf("%w: %v", ErrDeliveryUnavailable, err)
+	}
+
+	uc.spam.Record(contact)
+	log.Printf("[UseCase] Contact %s queued", contact.ID)
 	return &ContactOutput{
-		Success: true,
-		Message: "Email sent successfully",
+		Success:      true,
+		Message:      AcceptedMessage,
+		SubmissionID: contact.ID,
 	}, nil
 }
+
+// discardSubmission deletes the record of a submission the visitor was asked to resend
+func (uc *contactUseCase) discardSubmission(id string) {
+	if err := uc.submissions.Delete(id); err != nil {
+		log.Printf("[UseCase] Failed to delete submission %s: %v", id, err)
+	}
+}
+
+// Deliver sends a queued contact via the email repository, retrying transient
+// failures with backoff and moving the contact to the dead letters once exhausted
+func (uc *contactUseCase) Deliver(ctx context.Context, contact *entity.Contact) error {
+	var receipt *entity.DeliveryReceipt
+	var err error
+	attempts := 0
+
+	for attempts < uc.retryPolicy.MaxAttempts {
+		attempts++
+		receipt, err = uc.emailRepo.Send(contact)
+		uc.recordAttempt(contact, receipt, err)
+		if err == nil {
+			break
+		}
+
+		if repository.IsPermanent(err) || attempts == uc.retryPolicy.MaxAttempts {
+			break
+		}
+
+		delay := uc.retryPolicy.Backoff(attempts)
+		log.Printf("[UseCase] Attempt %d/%d for contact %s failed, retrying in %v: %v",
+			attempts, uc.retryPolicy.MaxAttempts, contact.ID, delay, entity.RedactAddresses(err.Error()))
+
+		select {
+		case <-ctx.Done():
+			// Shutting down; the contact stays pending in the outbox for replay
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if err != nil {
+		log.Printf("[UseCase] Giving up on contact %s after %d attempts: %v", contact.ID, attempts, entity.RedactAddresses(err.Error()))
+		if markErr := uc.outboxRepo.MarkFailed(contact.ID, err.Error(), attempts); markErr != nil {
+			log.Printf("[UseCase] Failed to update outbox for contact %s: %v", contact.ID, markErr)
+		}
+		if markErr := uc.submissions.MarkFailed(contact.ID, err.Error(), attempts); markErr != nil {
+			log.Printf("[UseCase] Failed to update submission %s: %v", contact.ID, markErr)
+		}
+		return err
+	}
+
+	if err := uc.outboxRepo.MarkSent(contact.ID); err != nil {
+		log.Printf("[UseCase] Failed to update outbox for contact %s: %v", contact.ID, err)
+	}
+	if err := uc.submissions.MarkSent(contact.ID, receipt, attempts); err != nil {
+		log.Printf("[UseCase] Failed to update submission %s: %v", contact.ID, err)
+	}
+
+	log.Printf("[UseCase] Email sent successfully for contact %s", contact.ID)
+
+	uc.sendAutoReply(contact)
+	return nil
+}
+
+// recordAttempt adds a delivery attempt to the submission's history
+func (uc *contactUseCase) recordAttempt(contact *entity.Contact, receipt *entity.DeliveryReceipt, err error) {
+	attempt := entity.NewDeliveryAttempt(contact.Recipients, receipt, err)
+	if addErr := uc.submissions.AddAttempt(contact.ID, attempt); addErr != nil {
+		log.Printf("[UseCase] Failed to record delivery attempt for submission %s: %v", contact.ID, addErr)
+	}
+}
+
+// sendAutoReply sends the submitter a confirmation once their contact has been
+// delivered. It is best effort: failures are logged and never retried.
+func (uc *contactUseCase) sendAutoReply(contact *entity.Contact) {
+	// Forms without an email field have nobody to reply to
+	if !uc.autoReply.Enabled || contact.Email == "" {
+		return
+	}
+
+	// Likely spam often carries a forged address; don't reply to it. With
+	// scoring on, a contact without a score was never checked, so it gets no
+	// reply either.
+	if uc.spam.Enabled() && contact.Spam == nil {
+		log.Printf("[UseCase] Auto-reply for contact %s skipped, the contact has no spam score", contact.ID)
+		return
+	}
+	if contact.Spam != nil && contact.Spam.Action != entity.SpamActionNone {
+		log.Printf("[UseCase] Auto-reply for contact %s skipped, the contact was scored as spam", contact.ID)
+		return
+	}
+
+	if !uc.replyThrottle.Allow(contact.Email) {
+		log.Printf("[UseCase] Auto-reply for contact %s skipped, the submitter was replied to recently", contact.ID)
+		return
+	}
+
+	if _, err := uc.emailRepo.SendConfirmation(contact); err != nil {
+		// Provider errors about an auto-reply are about the submitter's address,
+		// so only whether it can be retried is logged
+		log.Printf("[UseCase] Failed to send auto-reply for contact %s (permanent: %t)", contact.ID, repository.IsPermanent(err))
+		return
+	}
+
+	log.Printf("[UseCase] Auto-reply sent for contact %s", contact.ID)
+}
+
+// PendingDeliveries returns the contacts left in the outbox by a previous run
+func (uc *contactUseCase) PendingDeliveries() ([]*entity.Contact, error) {
+	return uc.outboxRepo.Pending()
+}
+
+// ResumePending re-queues contacts left in the outbox by a previous run
+func (uc *contactUseCase) ResumePending(ctx context.Context, contacts []*entity.Contact) (int, error) {
+	for i, contact := range contacts {
+		for {
+			err := uc.deliveryQueue.Enqueue(contact)
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, repository.ErrQueueFull) {
+				return i, err
+			}
+
+			// Wait for workers to make room instead of dropping the replay
+			select {
+			case <-ctx.Done():
+				return i, ctx.Err()
+			case <-time.After(resumeRetryInterval):
+			}
+		}
+	}
+
+	return len(contacts), nil
+}
+
+// newSubmissionID returns a time-ordered unique submission ID
+func newSubmissionID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
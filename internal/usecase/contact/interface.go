@@ -1,6 +1,33 @@
 package contact
 
-import "context"
+import (
+	"context"
+	"errors"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// Use case errors
+var (
+	// ErrDeliveryUnavailable is returned when a valid contact cannot be accepted for delivery
+	ErrDeliveryUnavailable = errors.New("delivery unavailable")
+
+	// ErrFormNotFound is returned when a submission targets an unknown form
+	ErrFormNotFound = errors.New("form not found")
+
+	// ErrSpamRejected is returned when a submission's spam score reaches the reject threshold
+	ErrSpamRejected = errors.New("rejected as spam")
+)
+
+// AcceptedMessage is the message returned for a submission queued for delivery
+const AcceptedMessage = "Message received and queued for delivery"
+
+// ClientInfo describes the client that sent a submission
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	Origin    string
+}
 
 // ContactInput represents the input for contact use case
 type ContactInput struct {
@@ -8,16 +35,38 @@ type ContactInput struct {
 	Email   string
 	Subject string
 	Message string
+	Client  ClientInfo
+}
+
+// FormInput represents the input for a configured form submission
+type FormInput struct {
+	FormID string
+	Values map[string]string
+	Client ClientInfo
 }
 
 // ContactOutput represents the output of contact use case
 type ContactOutput struct {
-	Success bool
-	Message string
+	Success      bool
+	Message      string
+	SubmissionID string
 }
 
 // UseCase defines the contact use case interface
 type UseCase interface {
-	// SendContact processes a contact form submission
+	// SendContact validates a contact form submission and queues it for delivery
 	SendContact(ctx context.Context, input *ContactInput) (*ContactOutput, error)
+
+	// SubmitForm validates a submission against a configured form and queues it for delivery
+	SubmitForm(ctx context.Context, input *FormInput) (*ContactOutput, error)
+
+	// Deliver sends a queued contact via the email repository, with retries
+	Deliver(ctx context.Context, contact *entity.Contact) error
+
+	// PendingDeliveries returns the contacts left undelivered by a previous run.
+	// Take it before accepting requests, so new contacts are not replayed too.
+	PendingDeliveries() ([]*entity.Contact, error)
+
+	// ResumePending re-queues contacts returned by PendingDeliveries
+	ResumePending(ctx context.Context, contacts []*entity.Contact) (int, error)
 }
@@ -0,0 +1,59 @@
+package contact
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// SpamPolicy rates the content of accepted submissions. Every scorer adds its
+// points to the score and the thresholds decide what happens to the contact.
+type SpamPolicy struct {
+	Scorers    []repository.SpamScorer // none disables scoring
+	Thresholds entity.SpamThresholds
+}
+
+// Enabled reports whether any scorer is configured
+func (p SpamPolicy) Enabled() bool {
+	return len(p.Scorers) > 0
+}
+
+// Score rates a contact, or returns nil when scoring is disabled
+func (p SpamPolicy) Score(contact *entity.Contact) *entity.SpamScore {
+	if len(p.Scorers) == 0 {
+		return nil
+	}
+
+	score := &entity.SpamScore{}
+	for _, s := range p.Scorers {
+		points, detail := s.Score(contact)
+		score.Add(s.Name(), points, detail)
+	}
+	score.Action = p.Thresholds.Action(score.Total)
+	return score
+}
+
+// Record passes an accepted contact to the scorers that remember contacts
+func (p SpamPolicy) Record(contact *entity.Contact) {
+	for _, s := range p.Scorers {
+		if r, ok := s.(repository.SpamRecorder); ok {
+			r.Record(contact)
+		}
+	}
+}
+
+// describeSpamScore returns a score with the reason for each rule, for logging
+func describeSpamScore(score *entity.SpamScore) string {
+	parts := make([]string, 0, len(score.Rules))
+	for _, r := range score.Rules {
+		parts = append(parts, fmt.Sprintf("%s=%.1f [%s]", r.Rule, r.Points, r.Detail))
+	}
+
+	action := string(score.Action)
+	if action == "" {
+		action = "none"
+	}
+	return fmt.Sprintf("%.1f {%s} action %s", score.Total, strings.Join(parts, ", "), action)
+}
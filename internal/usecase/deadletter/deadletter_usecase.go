@@ -0,0 +1,59 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// deadLetterUseCase implements the UseCase interface
+type deadLetterUseCase struct {
+	deadLetterRepo repository.DeadLetterRepository
+	outboxRepo     repository.OutboxRepository
+	deliveryQueue  repository.DeliveryQueue
+}
+
+// NewDeadLetterUseCase creates a new dead letter use case
+func NewDeadLetterUseCase(
+	deadLetterRepo repository.DeadLetterRepository,
+	outboxRepo repository.OutboxRepository,
+	deliveryQueue repository.DeliveryQueue,
+) UseCase {
+	return &deadLetterUseCase{
+		deadLetterRepo: deadLetterRepo,
+		outboxRepo:     outboxRepo,
+		deliveryQueue:  deliveryQueue,
+	}
+}
+
+// List returns all contacts whose delivery failed after all retries
+func (uc *deadLetterUseCase) List(ctx context.Context) ([]*entity.DeadLetter, error) {
+	return uc.deadLetterRepo.List()
+}
+
+// Redrive puts a dead letter back on the delivery queue
+func (uc *deadLetterUseCase) Redrive(ctx context.Context, id string) error {
+	letter, err := uc.deadLetterRepo.Requeue(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+		}
+		return err
+	}
+
+	contact := letter.Contact
+	if err := uc.deliveryQueue.Enqueue(contact); err != nil {
+		// Put it back as it was so it can be re-driven later
+		if markErr := uc.outboxRepo.MarkFailed(contact.ID, letter.Reason, letter.Attempts); markErr != nil {
+			log.Printf("[DeadLetterUseCase] Failed to restore dead letter %s: %v", contact.ID, markErr)
+		}
+		return fmt.Errorf("failed to enqueue dead letter %s: %w", contact.ID, err)
+	}
+
+	log.Printf("[DeadLetterUseCase] Re-driving contact %s", contact.ID)
+	return nil
+}
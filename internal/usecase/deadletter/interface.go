@@ -0,0 +1,20 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// ErrDeadLetterNotFound is returned when no dead letter exists for an ID
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// UseCase defines the dead letter use case interface
+type UseCase interface {
+	// List returns all contacts whose delivery failed after all retries
+	List(ctx context.Context) ([]*entity.DeadLetter, error)
+
+	// Redrive puts a dead letter back on the delivery queue
+	Redrive(ctx context.Context, id string) error
+}
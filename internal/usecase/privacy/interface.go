@@ -0,0 +1,47 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// ErrEmailRequired is returned when an erasure request has no email address
+var ErrEmailRequired = errors.New("email is required")
+
+// RetentionPolicy controls how long submitter data is kept
+type RetentionPolicy struct {
+	Period    time.Duration // zero keeps submissions forever
+	Anonymise bool          // anonymise old submissions instead of deleting them
+}
+
+// Enabled reports whether old submissions are purged at all
+func (p RetentionPolicy) Enabled() bool {
+	return p.Period > 0
+}
+
+// RetentionOutput reports what a retention run removed
+type RetentionOutput struct {
+	Cutoff      time.Time
+	Submissions int // deleted or anonymised, depending on the policy
+	DeadLetters int
+}
+
+// ErasureOutput reports what an erasure request removed
+type ErasureOutput struct {
+	Email       string
+	Submissions []*entity.Submission
+	Outbox      []string // IDs of pending contacts and dead letters
+}
+
+// UseCase defines the data retention and erasure use case interface
+type UseCase interface {
+	// ApplyRetention purges or anonymises submissions older than the retention
+	// period and deletes dead letters older than it
+	ApplyRetention(ctx context.Context) (*RetentionOutput, error)
+
+	// Erase deletes everything stored about a submitter address
+	Erase(ctx context.Context, email string) (*ErasureOutput, error)
+}
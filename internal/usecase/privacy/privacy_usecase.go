@@ -0,0 +1,90 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// privacyUseCase implements the UseCase interface
+type privacyUseCase struct {
+	submissions    repository.SubmissionRepository
+	outboxRepo     repository.OutboxRepository
+	deadLetterRepo repository.DeadLetterRepository
+	retention      RetentionPolicy
+}
+
+// NewPrivacyUseCase creates a new data retention and erasure use case
+func NewPrivacyUseCase(
+	submissions repository.SubmissionRepository,
+	outboxRepo repository.OutboxRepository,
+	deadLetterRepo repository.DeadLetterRepository,
+	retention RetentionPolicy,
+) UseCase {
+	return &privacyUseCase{
+		submissions:    submissions,
+		outboxRepo:     outboxRepo,
+		deadLetterRepo: deadLetterRepo,
+		retention:      retention,
+	}
+}
+
+// ApplyRetention purges or anonymises submissions older than the retention period
+func (uc *privacyUseCase) ApplyRetention(ctx context.Context) (*RetentionOutput, error) {
+	output := &RetentionOutput{}
+	if !uc.retention.Enabled() {
+		return output, nil
+	}
+	output.Cutoff = time.Now().UTC().Add(-uc.retention.Period)
+
+	var err error
+	if uc.retention.Anonymise {
+		output.Submissions, err = uc.submissions.AnonymiseBefore(output.Cutoff)
+	} else {
+		output.Submissions, err = uc.submissions.DeleteBefore(output.Cutoff)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// An anonymised dead letter could never be re-driven, so these are always deleted
+	if output.DeadLetters, err = uc.deadLetterRepo.DeleteBefore(output.Cutoff); err != nil {
+		return nil, err
+	}
+
+	if output.Submissions > 0 || output.DeadLetters > 0 {
+		log.Printf("[PrivacyUseCase] Retention removed data submitted before %s: %d submissions, %d dead letters",
+			output.Cutoff.Format(time.RFC3339), output.Submissions, output.DeadLetters)
+	}
+	return output, nil
+}
+
+// Erase deletes everything stored about a submitter address
+func (uc *privacyUseCase) Erase(ctx context.Context, email string) (*ErasureOutput, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, ErrEmailRequired
+	}
+
+	submissions, err := uc.submissions.DeleteByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase submissions: %w", err)
+	}
+	outbox, err := uc.outboxRepo.DeleteByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase outbox: %w", err)
+	}
+
+	// The address itself is not logged, or the log would keep what was erased
+	log.Printf("[PrivacyUseCase] Erasure removed %d submissions and %d outbox records", len(submissions), len(outbox))
+
+	return &ErasureOutput{
+		Email:       email,
+		Submissions: submissions,
+		Outbox:      outbox,
+	}, nil
+}
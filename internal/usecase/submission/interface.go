@@ -0,0 +1,60 @@
+package submission
+
+import (
+	"context"
+	"errors"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+)
+
+// Submission use case errors
+var (
+	ErrSubmissionNotFound   = errors.New("submission not found")
+	ErrInvalidRecipient     = errors.New("invalid recipient")
+	ErrResendFailed         = errors.New("resend failed")
+	ErrSubmissionAnonymised = errors.New("submission was anonymised and has nothing to resend")
+)
+
+// Page size limits for List
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// ListInput represents a page of the submission history to fetch
+type ListInput struct {
+	Filter  entity.SubmissionFilter
+	Page    int // 1-based; defaults to 1
+	PerPage int // defaults to DefaultPerPage, capped at MaxPerPage
+}
+
+// ListOutput represents a page of the submission history
+type ListOutput struct {
+	Submissions []*entity.Submission
+	Page        int
+	PerPage     int
+	Total       int // submissions matching the filter across all pages
+}
+
+// ResendInput represents a request to send a stored submission again
+type ResendInput struct {
+	ID string
+	To string // replaces the stored recipients when set
+}
+
+// UseCase defines the submission history use case interface
+type UseCase interface {
+	// List returns a page of submissions matching the filter, newest first
+	List(ctx context.Context, input ListInput) (*ListOutput, error)
+
+	// Export calls fn for every submission matching the filter, oldest first
+	Export(ctx context.Context, filter entity.SubmissionFilter, fn func(*entity.Submission) error) error
+
+	// Get returns a single submission
+	Get(ctx context.Context, id string) (*entity.Submission, error)
+
+	// Resend sends a stored submission through the email repository again and
+	// records the attempt in its delivery history. The attempt is returned even
+	// when sending fails.
+	Resend(ctx context.Context, input ResendInput) (*entity.DeliveryAttempt, error)
+}
@@ -0,0 +1,125 @@
+package submission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/andrianprasetya/go-mail-server/internal/domain/entity"
+	"github.com/andrianprasetya/go-mail-server/internal/domain/repository"
+)
+
+// submissionUseCase implements the UseCase interface
+type submissionUseCase struct {
+	submissions repository.SubmissionRepository
+	emailRepo   repository.EmailRepository
+	outboxRepo  repository.OutboxRepository
+}
+
+// NewSubmissionUseCase creates a new submission history use case
+func NewSubmissionUseCase(
+	submissions repository.SubmissionRepository,
+	emailRepo repository.EmailRepository,
+	outboxRepo repository.OutboxRepository,
+) UseCase {
+	return &submissionUseCase{
+		submissions: submissions,
+		emailRepo:   emailRepo,
+		outboxRepo:  outboxRepo,
+	}
+}
+
+// List returns a page of submissions matching the filter, newest first
+func (uc *submissionUseCase) List(ctx context.Context, input ListInput) (*ListOutput, error) {
+	page := input.Page
+	if page < 1 {
+		page = 1
+	}
+	perPage := input.PerPage
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+
+	submissions, total, err := uc.submissions.List(input.Filter, (page-1)*perPage, perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submissions: %w", err)
+	}
+
+	return &ListOutput{
+		Submissions: submissions,
+		Page:        page,
+		PerPage:     perPage,
+		Total:       total,
+	}, nil
+}
+
+// Export calls fn for every submission matching the filter, oldest first
+func (uc *submissionUseCase) Export(ctx context.Context, filter entity.SubmissionFilter, fn func(*entity.Submission) error) error {
+	if err := uc.submissions.Each(filter, fn); err != nil {
+		return fmt.Errorf("failed to export submissions: %w", err)
+	}
+	return nil
+}
+
+// Get returns a single submission
+func (uc *submissionUseCase) Get(ctx context.Context, id string) (*entity.Submission, error) {
+	submission, err := uc.submissions.Get(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSubmissionNotFound, id)
+		}
+		return nil, err
+	}
+	return submission, nil
+}
+
+// Resend sends a stored submission through the email repository again
+func (uc *submissionUseCase) Resend(ctx context.Context, input ResendInput) (*entity.DeliveryAttempt, error) {
+	submission, err := uc.Get(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if submission.Anonymised {
+		return nil, ErrSubmissionAnonymised
+	}
+
+	contact := submission.Contact
+	if input.To != "" {
+		recipients := entity.NewRecipients([]string{input.To}, nil, nil)
+		if err := recipients.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRecipient, err)
+		}
+		contact.Recipients = recipients
+	}
+
+	receipt, sendErr := uc.emailRepo.Send(contact)
+	attempt := entity.NewDeliveryAttempt(contact.Recipients, receipt, sendErr)
+	attempt.Resend = true
+	if err := uc.submissions.AddAttempt(contact.ID, attempt); err != nil {
+		log.Printf("[SubmissionUseCase] Failed to record resend of submission %s: %v", contact.ID, err)
+	}
+
+	if sendErr != nil {
+		log.Printf("[SubmissionUseCase] Resend of submission %s to %s failed: %v", contact.ID, contact.Recipients, entity.RedactAddresses(sendErr.Error()))
+		return &attempt, fmt.Errorf("%w: %v", ErrResendFailed, sendErr)
+	}
+	log.Printf("[SubmissionUseCase] Resent submission %s to %s", contact.ID, contact.Recipients)
+
+	// Resending a dead letter or a quarantined submission to its original
+	// recipients completes its delivery. Queued submissions are left to the
+	// delivery queue.
+	if input.To == "" && (submission.Status == entity.SubmissionFailed || submission.Status == entity.SubmissionQuarantined) {
+		if err := uc.outboxRepo.Remove(contact.ID); err != nil {
+			log.Printf("[SubmissionUseCase] Failed to drop dead letter %s: %v", contact.ID, err)
+		}
+		if err := uc.submissions.MarkSent(contact.ID, receipt, submission.Attempts); err != nil {
+			log.Printf("[SubmissionUseCase] Failed to update submission %s: %v", contact.ID, err)
+		}
+	}
+
+	return &attempt, nil
+}